@@ -0,0 +1,151 @@
+// Package sqlvalue converts values between the MindsDB SQL API and Go for
+// the minds package and the database/sql driver.
+package sqlvalue
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts are the formats date and time values are returned in.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// DriverValue converts a decoded result value into the form expected by
+// sql.Scanner and database/sql drivers: numbers become int64 when they are
+// integers and float64 otherwise.
+func DriverValue(value interface{}) interface{} {
+	n, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return n.String()
+}
+
+// ParseInt converts a decoded result value into an integer. Numbers with a
+// fractional part are rejected.
+func ParseInt(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n, nil
+		}
+		f, err := v.Float64()
+		if err != nil || f != math.Trunc(f) {
+			return 0, fmt.Errorf("cannot convert %s to an integer", v)
+		}
+		return int64(f), nil
+	case string:
+		return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return 0, fmt.Errorf("cannot convert %T to an integer", value)
+}
+
+// ParseFloat converts a decoded result value into a float.
+func ParseFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	}
+	return 0, fmt.Errorf("cannot convert %T to a float", value)
+}
+
+// ParseBool converts a decoded result value into a bool. Numbers are true
+// when they are not zero.
+func ParseBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case json.Number:
+		f, err := v.Float64()
+		return f != 0, err
+	case string:
+		return strconv.ParseBool(strings.TrimSpace(v))
+	}
+	return false, fmt.Errorf("cannot convert %T to a bool", value)
+}
+
+// ParseTime converts a decoded date or time value into a time.Time. Strings
+// are parsed in the formats the SQL API returns, and numbers are taken as
+// seconds since the Unix epoch.
+func ParseTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case string:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("cannot parse %q as a time", v)
+	case json.Number:
+		// Numeric timestamps are seconds since the Unix epoch.
+		f, err := v.Float64()
+		if err != nil {
+			return time.Time{}, err
+		}
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("cannot convert %T to a time", value)
+}
+
+// Literal formats value as a SQL literal. Lists and nested options are
+// passed as JSON.
+func Literal(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return QuoteString(v)
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case []byte:
+		return "x'" + hex.EncodeToString(v) + "'"
+	case time.Time:
+		return QuoteString(v.Format("2006-01-02 15:04:05.999999"))
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return QuoteString(fmt.Sprint(v))
+		}
+		return string(data)
+	}
+}
+
+// QuoteString quotes s as a SQL string, escaping backslashes and quotes.
+func QuoteString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `''`)
+	return "'" + s + "'"
+}
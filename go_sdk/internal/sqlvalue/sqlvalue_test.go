@@ -0,0 +1,81 @@
+package sqlvalue
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDriverValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  interface{}
+	}{
+		{json.Number("3"), int64(3)},
+		{json.Number("3.5"), float64(3.5)},
+		{"x", "x"},
+		{nil, nil},
+	}
+	for _, tt := range tests {
+		if got := DriverValue(tt.value); got != tt.want {
+			t.Errorf("DriverValue(%v) = %#v, want %#v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		value   interface{}
+		want    int64
+		wantErr bool
+	}{
+		{json.Number("3"), 3, false},
+		{json.Number("3e2"), 300, false},
+		{json.Number("3.5"), 0, true},
+		{" 12 ", 12, false},
+		{true, 1, false},
+		{[]interface{}{}, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseInt(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseInt(%#v) = %d, %v, want %d, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	want := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, value := range []interface{}{"2024-01-02 03:04:05", "2024-01-02T03:04:05Z", json.Number("1704164645")} {
+		got, err := ParseTime(value)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseTime(%#v) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+	if _, err := ParseTime(true); err == nil {
+		t.Error("ParseTime(true) returned no error")
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{nil, "NULL"},
+		{"it's", "'it''s'"},
+		{`a\b`, `'a\\b'`},
+		{true, "TRUE"},
+		{42, "42"},
+		{int64(-7), "-7"},
+		{0.5, "0.5"},
+		{[]byte{0xde, 0xad}, "x'dead'"},
+		{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "'2024-01-02 03:04:05'"},
+		{[]string{"a", "b"}, `["a","b"]`},
+	}
+	for _, tt := range tests {
+		if got := Literal(tt.value); got != tt.want {
+			t.Errorf("Literal(%#v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
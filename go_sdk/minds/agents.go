@@ -0,0 +1,391 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Agent represents a MindsDB agent, an LLM with a set of skills.
+type Agent struct {
+	api        *RestAPI
+	client     *Client
+	Project    string                 `json:"project"`
+	Name       string                 `json:"name"`
+	ModelName  string                 `json:"model_name"`
+	Provider   string                 `json:"provider"`
+	Skills     []*Skill               `json:"skills"`
+	Parameters map[string]interface{} `json:"params"`
+	CreatedAt  string                 `json:"created_at"`
+	UpdatedAt  string                 `json:"updated_at"`
+}
+
+// CreateAgentOptions holds the optional settings of a new agent. Skills
+// accepts names, *Skill, or *SkillConfig values which are created if missing.
+type CreateAgentOptions struct {
+	ModelName  *string
+	Provider   *string
+	Skills     []interface{}
+	Parameters map[string]interface{}
+}
+
+// UpdateAgentOptions holds the agent settings to change; nil fields are kept.
+type UpdateAgentOptions struct {
+	ModelName  *string
+	Provider   *string
+	Parameters map[string]interface{}
+}
+
+// Update updates an agent's configuration.
+func (a *Agent) Update(updateOpts *UpdateAgentOptions) error {
+	return a.UpdateContext(context.Background(), updateOpts)
+}
+
+// UpdateContext is like Update with a context.
+func (a *Agent) UpdateContext(ctx context.Context, updateOpts *UpdateAgentOptions) (err error) {
+	ctx, span := a.api._startSpan(ctx, "Agent.Update", AttrAgent, a.Name)
+	defer func() { _endSpan(span, err) }()
+
+	data := make(map[string]interface{})
+	if updateOpts.ModelName != nil {
+		data["model_name"] = *updateOpts.ModelName
+	}
+	if updateOpts.Provider != nil {
+		data["provider"] = *updateOpts.Provider
+	}
+	if updateOpts.Parameters != nil {
+		data["params"] = updateOpts.Parameters
+	}
+
+	if err := a._put(ctx, data); err != nil {
+		return fmt.Errorf("error updating agent: %w", err)
+	}
+	return a._refresh(ctx)
+}
+
+// AddSkill attaches a skill to the agent. It accepts a name, a *Skill, or a
+// *SkillConfig which is created if missing.
+func (a *Agent) AddSkill(skill interface{}) error {
+	return a.AddSkillContext(context.Background(), skill)
+}
+
+// AddSkillContext is like AddSkill with a context.
+func (a *Agent) AddSkillContext(ctx context.Context, skill interface{}) (err error) {
+	ctx, span := a.api._startSpan(ctx, "Agent.AddSkill", AttrAgent, a.Name)
+	defer func() { _endSpan(span, err) }()
+
+	skillName, err := NewSkills(a.client, a.Project)._checkSkill(ctx, skill)
+	if err != nil {
+		return fmt.Errorf("error checking skill: %w", err)
+	}
+	span.SetAttribute(AttrSkill, skillName)
+	if err := a._put(ctx, map[string]interface{}{"skills_to_add": []string{skillName}}); err != nil {
+		return fmt.Errorf("error adding skill to agent: %w", err)
+	}
+	return a._refresh(ctx)
+}
+
+// RemoveSkill detaches a skill, given by name or object, from the agent.
+func (a *Agent) RemoveSkill(skill interface{}) error {
+	return a.RemoveSkillContext(context.Background(), skill)
+}
+
+// RemoveSkillContext is like RemoveSkill with a context.
+func (a *Agent) RemoveSkillContext(ctx context.Context, skill interface{}) (err error) {
+	ctx, span := a.api._startSpan(ctx, "Agent.RemoveSkill", AttrAgent, a.Name)
+	defer func() { _endSpan(span, err) }()
+
+	var skillName string
+	switch s := skill.(type) {
+	case string:
+		skillName = s
+	case *Skill:
+		skillName = s.Name
+	default:
+		return fmt.Errorf("unknown skill type: %T", skill)
+	}
+	span.SetAttribute(AttrSkill, skillName)
+	if err := a._put(ctx, map[string]interface{}{"skills_to_remove": []string{skillName}}); err != nil {
+		return fmt.Errorf("error removing skill from agent: %w", err)
+	}
+	return a._refresh(ctx)
+}
+
+// agentMessage is a turn of an agent conversation. The turn being asked has
+// no answer yet.
+type agentMessage struct {
+	Question string  `json:"question"`
+	Answer   *string `json:"answer"`
+}
+
+// agentStreamChunk is an event of a streamed agent completion. The answer is
+// carried by the chunks with an output; the others report the agent's steps.
+type agentStreamChunk struct {
+	Type    string `json:"type"`
+	Output  string `json:"output"`
+	Content string `json:"content"`
+}
+
+// Completion sends a message to the agent and returns its answer. Streamed
+// answers are accumulated into a single string. The agent endpoint does not
+// report token usage, so agent completions count against the usage budget
+// but are not recorded by it.
+func (a *Agent) Completion(message string, useStream bool) (string, error) {
+	return a.CompletionContext(context.Background(), message, useStream)
+}
+
+// CompletionContext is like Completion with a context.
+func (a *Agent) CompletionContext(ctx context.Context, message string, useStream bool) (_ string, err error) {
+	ctx, span := a.api._startSpan(ctx, "Agent.Completion", AttrAgent, a.Name, AttrStream, useStream)
+	defer func() { _endSpan(span, err) }()
+
+	call, err := _startCompletion(ctx, a.api, a.Name, useStream)
+	if err != nil {
+		return "", err
+	}
+	defer func() { call._done(err) }()
+
+	path := fmt.Sprintf("/projects/%s/agents/%s/completions", url.PathEscape(a.Project), url.PathEscape(a.Name))
+	data := map[string]interface{}{"messages": []agentMessage{{Question: message}}}
+
+	if !useStream {
+		resp, err := a.api.post(ctx, path, data)
+		if err != nil {
+			return "", fmt.Errorf("error creating agent completion: %w", err)
+		}
+		defer resp.Body.Close()
+
+		var completion struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+			return "", fmt.Errorf("error decoding agent completion: %w", err)
+		}
+		return completion.Message.Content, nil
+	}
+
+	resp, err := a.api.post(ctx, path+"/stream", data)
+	if err != nil {
+		return "", fmt.Errorf("error creating agent completion stream: %w", err)
+	}
+	defer resp.Body.Close()
+	a.api._log(LevelDebug, "completion stream opened", "agent", a.Name)
+
+	var fullResponse strings.Builder
+	err = _readEvents(resp.Body, func(data string) (bool, error) {
+		var chunk agentStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return false, fmt.Errorf("error decoding agent completion chunk: %w", err)
+		}
+		if chunk.Type == "error" {
+			return false, &UnknownError{Message: chunk.Content}
+		}
+		if chunk.Output != "" {
+			call._output()
+			fullResponse.WriteString(chunk.Output)
+		}
+		return chunk.Type == "end", nil
+	})
+	if err != nil {
+		a.api._log(LevelWarn, "completion stream failed", "agent", a.Name, "chunks", call.chunks, "error", err)
+		return "", fmt.Errorf("error receiving agent completion stream: %w", err)
+	}
+	a.api._log(LevelDebug, "completion stream closed", "agent", a.Name, "chunks", call.chunks)
+	return fullResponse.String(), nil
+}
+
+func (a *Agent) _put(ctx context.Context, agent map[string]interface{}) error {
+	resp, err := a.api.put(
+		ctx,
+		fmt.Sprintf("/projects/%s/agents/%s", a.Project, a.Name),
+		map[string]interface{}{"agent": agent},
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (a *Agent) _refresh(ctx context.Context) error {
+	updatedAgent, err := NewAgents(a.client, a.Project).GetContext(ctx, a.Name)
+	if err != nil {
+		return fmt.Errorf("error getting updated agent: %w", err)
+	}
+	*a = *updatedAgent
+	return nil
+}
+
+// Agents manages the agents of a project.
+type Agents struct {
+	api     *RestAPI
+	client  *Client
+	project string
+}
+
+// NewAgents creates a new Agents instance scoped to project.
+func NewAgents(client *Client, project string) *Agents {
+	return &Agents{
+		api:     client.api,
+		client:  client,
+		project: project,
+	}
+}
+
+// Create creates a new agent.
+func (as *Agents) Create(name string, opts *CreateAgentOptions, replace bool) (*Agent, error) {
+	return as.CreateContext(context.Background(), name, opts, replace)
+}
+
+// CreateContext is like Create with a context.
+func (as *Agents) CreateContext(ctx context.Context, name string, opts *CreateAgentOptions, replace bool) (_ *Agent, err error) {
+	ctx, span := as.api._startSpan(ctx, "Agents.Create", AttrAgent, name)
+	defer func() { _endSpan(span, err) }()
+
+	if replace {
+		_, err := as.GetContext(ctx, name)
+		if err == nil {
+			err = as.DropContext(ctx, name)
+			if err != nil {
+				return nil, fmt.Errorf("error replacing agent: %w", err)
+			}
+		} else if !errors.As(err, new(*ObjectNotFound)) {
+			return nil, fmt.Errorf("error checking for existing agent: %w", err)
+		}
+	}
+
+	data := map[string]interface{}{
+		"name": name,
+	}
+	if opts != nil {
+		if opts.ModelName != nil {
+			data["model_name"] = *opts.ModelName
+		}
+		if opts.Provider != nil {
+			data["provider"] = *opts.Provider
+		}
+		if opts.Parameters != nil {
+			data["params"] = opts.Parameters
+		}
+		if opts.Skills != nil {
+			skillNames := make([]string, 0, len(opts.Skills))
+			for _, skill := range opts.Skills {
+				skillName, err := NewSkills(as.client, as.project)._checkSkill(ctx, skill)
+				if err != nil {
+					return nil, fmt.Errorf("error checking skill: %w", err)
+				}
+				skillNames = append(skillNames, skillName)
+			}
+			data["skills"] = skillNames
+		}
+	}
+
+	resp, err := as.api.post(
+		ctx,
+		fmt.Sprintf("/projects/%s/agents", as.project),
+		map[string]interface{}{"agent": data},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating agent: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return as.GetContext(ctx, name)
+}
+
+// List returns all agents in the project.
+func (as *Agents) List() ([]*Agent, error) {
+	return as.ListContext(context.Background())
+}
+
+// ListContext is like List with a context.
+func (as *Agents) ListContext(ctx context.Context) (_ []*Agent, err error) {
+	ctx, span := as.api._startSpan(ctx, "Agents.List")
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := as.api.get(ctx, fmt.Sprintf("/projects/%s/agents", as.project))
+	if err != nil {
+		return nil, fmt.Errorf("error listing agents: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var data []*Agent
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("error decoding agents response: %w", err)
+	}
+	for _, agent := range data {
+		as._bind(agent)
+	}
+	return data, nil
+}
+
+// Get retrieves an agent by name.
+func (as *Agents) Get(name string) (*Agent, error) {
+	return as.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get with a context.
+func (as *Agents) GetContext(ctx context.Context, name string) (_ *Agent, err error) {
+	ctx, span := as.api._startSpan(ctx, "Agents.Get", AttrAgent, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := as.api.get(ctx, fmt.Sprintf("/projects/%s/agents/%s", as.project, name))
+	if err != nil {
+		return nil, fmt.Errorf("error getting agent: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var agent Agent
+	if err := json.NewDecoder(resp.Body).Decode(&agent); err != nil {
+		return nil, fmt.Errorf("error decoding agent response: %w", err)
+	}
+	as._bind(&agent)
+	return &agent, nil
+}
+
+// Drop deletes an agent by name.
+func (as *Agents) Drop(name string) error {
+	return as.DropContext(context.Background(), name)
+}
+
+// DropContext is like Drop with a context.
+func (as *Agents) DropContext(ctx context.Context, name string) (err error) {
+	ctx, span := as.api._startSpan(ctx, "Agents.Drop", AttrAgent, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := as.api.delete(ctx, fmt.Sprintf("/projects/%s/agents/%s", as.project, name))
+	if err != nil {
+		return fmt.Errorf("error deleting agent: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (as *Agents) _bind(agent *Agent) {
+	agent.api = as.api
+	agent.client = as.client
+	agent.Project = as.project
+	for _, skill := range agent.Skills {
+		skill.api = as.api
+		skill.Project = as.project
+	}
+}
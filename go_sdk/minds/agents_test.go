@@ -0,0 +1,144 @@
+package minds
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Responses recorded from the agent completion endpoints.
+const (
+	recordedAgentCompletion = `{"message": {"content": "There were 42 orders last week.", "role": "assistant"}}`
+
+	recordedAgentStream = "data: {\"type\": \"start\", \"prompt\": \"How many orders last week?\"}\n\n" +
+		"data: {\"actions\": [{\"tool\": \"sql_db_query\", \"tool_input\": \"SELECT COUNT(*) FROM orders\"}]}\n\n" +
+		"data: {\"steps\": [{\"observation\": \"[(42,)]\"}]}\n\n" +
+		"data: {\"output\": \"There were 42 \"}\n\n" +
+		"data: {\"output\": \"orders last week.\"}\n\n" +
+		"data: {\"type\": \"end\"}\n\n"
+)
+
+func TestAgentCompletion(t *testing.T) {
+	tests := []struct {
+		name      string
+		useStream bool
+		path      string
+		response  string
+	}{
+		{"plain", false, "/api/projects/mindsdb/agents/sales%20bot/completions", recordedAgentCompletion},
+		{"stream", true, "/api/projects/mindsdb/agents/sales%20bot/completions/stream", recordedAgentStream},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			var payload struct {
+				Messages []agentMessage `json:"messages"`
+			}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.EscapedPath()
+				json.NewDecoder(r.Body).Decode(&payload)
+				w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+
+			client := NewClient("key", srv.URL)
+			agent := &Agent{api: client.api, client: client, Project: "mindsdb", Name: "sales bot"}
+			answer, err := agent.Completion("How many orders last week?", tt.useStream)
+			if err != nil {
+				t.Fatalf("Completion() error = %v", err)
+			}
+			if answer != "There were 42 orders last week." {
+				t.Errorf("Completion() = %q", answer)
+			}
+			if path != tt.path {
+				t.Errorf("path = %q, want %q", path, tt.path)
+			}
+			want := []agentMessage{{Question: "How many orders last week?"}}
+			if !reflect.DeepEqual(payload.Messages, want) {
+				t.Errorf("messages = %+v, want %+v", payload.Messages, want)
+			}
+		})
+	}
+}
+
+func TestAgentCompletionStreamError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: {\"type\": \"error\", \"content\": \"model unavailable\"}\n\n"))
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", srv.URL)
+	agent := &Agent{api: client.api, client: client, Project: "mindsdb", Name: "bot"}
+	if _, err := agent.Completion("hi", true); err == nil {
+		t.Error("Completion() returned no error")
+	}
+}
+
+// completionMetrics records the completions reported to it.
+type completionMetrics struct {
+	timeToFirstToken time.Duration
+	chunks           int
+	failed           bool
+}
+
+func (m *completionMetrics) ObserveRequest(method string, path string, statusClass string, duration time.Duration) {
+}
+
+func (m *completionMetrics) ObserveOperation(operation string, failed bool, duration time.Duration) {}
+
+func (m *completionMetrics) ObserveCompletion(model string, stream bool, failed bool, timeToFirstToken time.Duration, duration time.Duration, chunks int) {
+	m.timeToFirstToken = timeToFirstToken
+	m.chunks = chunks
+	m.failed = failed
+}
+
+func TestAgentCompletionStreamCountsOutputChunks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(recordedAgentStream))
+	}))
+	defer srv.Close()
+
+	metrics := &completionMetrics{}
+	client := NewClient("key", srv.URL, WithMetrics(metrics))
+	agent := &Agent{api: client.api, client: client, Project: "mindsdb", Name: "bot"}
+	if _, err := agent.Completion("How many orders last week?", true); err != nil {
+		t.Fatalf("Completion() error = %v", err)
+	}
+	// Only the two output events count; start, action and end events do not.
+	if metrics.chunks != 2 || metrics.failed {
+		t.Errorf("chunks = %d, failed = %v, want 2 chunks", metrics.chunks, metrics.failed)
+	}
+	if metrics.timeToFirstToken <= 0 {
+		t.Errorf("timeToFirstToken = %v, want it measured at the first output", metrics.timeToFirstToken)
+	}
+}
+
+func TestAgentsUseTheirProjectForSkills(t *testing.T) {
+	srv, requests := recordingServer(t, map[string]string{
+		"GET /api/projects/sales/agents/bot": `{"name": "bot"}`,
+	})
+	client := NewClient("key", srv.URL)
+
+	opts := &CreateAgentOptions{Skills: []interface{}{Text2SQLSkill("orders_sql", "shop", []string{"orders"}, "Orders")}}
+	agent, err := NewAgents(client, "sales").Create("bot", opts, false)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if got := (*requests)[0].Path; got != "/api/projects/sales/skills/orders_sql" {
+		t.Errorf("skill lookup path = %q, want the agents' project", got)
+	}
+	if agent.Project != "sales" {
+		t.Errorf("Project = %q, want sales", agent.Project)
+	}
+
+	if err := agent.AddSkill("faq"); err != nil {
+		t.Fatalf("AddSkill() error = %v", err)
+	}
+	last := (*requests)[len(*requests)-1]
+	if last.Method != http.MethodGet || last.Path != "/api/projects/sales/agents/bot" {
+		t.Errorf("refresh request = %s %s, want the agent's project", last.Method, last.Path)
+	}
+}
@@ -0,0 +1,317 @@
+package minds
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Authenticator adds credentials to outgoing requests.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// Refresher is implemented by authenticators that can renew their
+// credentials. When the server answers 401, the request is retried once
+// after a successful Refresh.
+type Refresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// BearerAuth sends an API key as a bearer token.
+type BearerAuth struct {
+	APIKey string
+}
+
+// Authenticate sets the Authorization header.
+func (a *BearerAuth) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.APIKey)
+	return nil
+}
+
+// NoAuth sends requests without credentials, for servers with
+// authentication disabled.
+type NoAuth struct{}
+
+// Authenticate leaves the request unchanged.
+func (NoAuth) Authenticate(req *http.Request) error {
+	return nil
+}
+
+// SessionAuth logs in to a self-hosted MindsDB with a username and password
+// and sends the session cookie with each request. It logs in on first use
+// and again when the session expires; concurrent requests share one login.
+//
+// The session of the server given to NewSessionAuth also authenticates its
+// ai.<host> completion endpoint used by Mind.Completion. With an
+// EndpointPool, each deployment of the pool has its own session. Requests
+// to other hosts are rejected with InvalidConfig.
+type SessionAuth struct {
+	Username string
+	Password string
+	// Client is used for login requests; its cookie jar holds the sessions.
+	Client *http.Client
+
+	mu      sync.Mutex
+	primary *sessionDeployment
+	// deployments maps the REST and completion hosts of each deployment to
+	// its session.
+	deployments map[string]*sessionDeployment
+}
+
+// sessionDeployment is the session of one MindsDB deployment.
+type sessionDeployment struct {
+	loginURL *url.URL
+	loggedIn bool
+	// session counts successful logins, so that requests which failed with
+	// an older session do not log in again.
+	session int
+	login   *sessionLogin
+}
+
+// sessionLogin is a login in progress, shared by the requests waiting for it.
+type sessionLogin struct {
+	done chan struct{}
+	err  error
+}
+
+// loginTimeout bounds a login, which runs independently of the requests
+// waiting for it.
+const loginTimeout = 30 * time.Second
+
+// NewSessionAuth creates a SessionAuth for the server at baseURL, given in
+// the same form as to NewClient.
+func NewSessionAuth(baseURL string, username string, password string) *SessionAuth {
+	jar, _ := cookiejar.New(nil)
+	a := &SessionAuth{
+		Username:    username,
+		Password:    password,
+		Client:      &http.Client{Jar: jar},
+		deployments: make(map[string]*sessionDeployment),
+	}
+	a.primary = a._addDeployment(_normalizeBaseURL(baseURL), "")
+	return a
+}
+
+// _addDeployment registers the deployment serving the REST API at the
+// normalized baseURL and completions at llmURL, which is derived from
+// baseURL when empty.
+func (a *SessionAuth) _addDeployment(baseURL string, llmURL string) *sessionDeployment {
+	loginURL, _ := url.Parse(baseURL + "login")
+	if llmURL == "" {
+		llmURL, _ = _deriveLLMURL(baseURL)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	d, ok := a.deployments[loginURL.Host]
+	if !ok {
+		d = &sessionDeployment{loginURL: loginURL}
+		a.deployments[loginURL.Host] = d
+	}
+	if u, err := url.Parse(llmURL); err == nil && u.Host != "" {
+		if _, ok := a.deployments[u.Host]; !ok {
+			a.deployments[u.Host] = d
+		}
+	}
+	return d
+}
+
+// _deployment returns the deployment whose session authenticates host.
+func (a *SessionAuth) _deployment(host string) (*sessionDeployment, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	d, ok := a.deployments[host]
+	if !ok {
+		return nil, &InvalidConfig{Message: fmt.Sprintf("session authentication for %s cannot authenticate requests to %s", a.primary.loginURL.Host, host)}
+	}
+	return d, nil
+}
+
+// Authenticate logs in if needed and adds the session cookies to req.
+func (a *SessionAuth) Authenticate(req *http.Request) error {
+	_, err := a._authenticate(req)
+	return err
+}
+
+// _authenticate is like Authenticate and returns the session it used.
+func (a *SessionAuth) _authenticate(req *http.Request) (int, error) {
+	d, err := a._deployment(req.URL.Host)
+	if err != nil {
+		return 0, err
+	}
+
+	a.mu.Lock()
+	loggedIn, session := d.loggedIn, d.session
+	a.mu.Unlock()
+	if !loggedIn {
+		if err := a._refreshSession(req.Context(), d, session); err != nil {
+			return 0, err
+		}
+		a.mu.Lock()
+		session = d.session
+		a.mu.Unlock()
+	}
+
+	// The cookies are those of the REST host, which the completion endpoint
+	// of the same deployment accepts as well.
+	for _, cookie := range a.Client.Jar.Cookies(d.loginURL) {
+		req.AddCookie(cookie)
+	}
+	return session, nil
+}
+
+// Refresh logs in to the server given to NewSessionAuth, replacing the
+// current session. A call made while another login is in progress waits for
+// that login instead.
+func (a *SessionAuth) Refresh(ctx context.Context) error {
+	a.mu.Lock()
+	session := a.primary.session
+	a.mu.Unlock()
+	return a._refreshSession(ctx, a.primary, session)
+}
+
+// _refreshHost logs in to the deployment serving host unless a login newer
+// than stale has completed.
+func (a *SessionAuth) _refreshHost(ctx context.Context, host string, stale int) error {
+	d, err := a._deployment(host)
+	if err != nil {
+		return err
+	}
+	return a._refreshSession(ctx, d, stale)
+}
+
+// _refreshSession logs in to d unless a login newer than stale has
+// completed. The login is not bound to ctx, so that a caller giving up does
+// not fail the others waiting for the same login.
+func (a *SessionAuth) _refreshSession(ctx context.Context, d *sessionDeployment, stale int) error {
+	a.mu.Lock()
+	if d.loggedIn && d.session != stale {
+		a.mu.Unlock()
+		return nil
+	}
+	login := d.login
+	if login == nil {
+		login = &sessionLogin{done: make(chan struct{})}
+		d.login = login
+		go a._runLogin(d, login)
+	}
+	a.mu.Unlock()
+
+	select {
+	case <-login.done:
+		return login.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *SessionAuth) _runLogin(d *sessionDeployment, login *sessionLogin) {
+	ctx, cancel := context.WithTimeout(context.Background(), loginTimeout)
+	defer cancel()
+	login.err = a._login(ctx, d.loginURL)
+
+	a.mu.Lock()
+	d.loggedIn = login.err == nil
+	if d.loggedIn {
+		d.session++
+	}
+	d.login = nil
+	a.mu.Unlock()
+	close(login.done)
+}
+
+func (a *SessionAuth) _login(ctx context.Context, loginURL *url.URL) error {
+	jsonData, err := json.Marshal(map[string]string{
+		"username": a.Username,
+		"password": a.Password,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", loginURL.String(), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error logging in: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &Unauthorized{Message: fmt.Sprintf("login failed: %d, body: %s", resp.StatusCode, string(body))}
+	}
+	return nil
+}
+
+// authTransport authenticates each request and retries once after
+// refreshing credentials when the server answers 401.
+type authTransport struct {
+	api  *RestAPI
+	auth Authenticator
+	base http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, used, err := t._roundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	refresher, ok := t.auth.(Refresher)
+	if !ok || !_canReplay(req) {
+		// The credentials cannot be renewed or the body cannot be replayed.
+		return resp, nil
+	}
+	refresh := refresher.Refresh
+	if session, ok := t.auth.(*SessionAuth); ok {
+		// Requests failing together with the same session share one login.
+		refresh = func(ctx context.Context) error { return session._refreshHost(ctx, req.URL.Host, used) }
+	}
+	if err := refresh(req.Context()); err != nil {
+		t.api._log(LevelWarn, "credential refresh failed", "path", _apiPath(req.URL.Path), "error", err)
+		return resp, nil
+	}
+	resp.Body.Close()
+	_countRetry(req.Context())
+	t.api._log(LevelInfo, "retrying after credential refresh", "method", req.Method, "path", _apiPath(req.URL.Path))
+
+	retry, err := _replay(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, _, err = t._roundTrip(retry)
+	return resp, err
+}
+
+// _roundTrip authenticates and sends req. It returns the session used when
+// authenticating with a SessionAuth.
+func (t *authTransport) _roundTrip(req *http.Request) (*http.Response, int, error) {
+	// A RoundTripper must not modify the caller's request.
+	authReq := req.Clone(req.Context())
+	var session int
+	var err error
+	if sessionAuth, ok := t.auth.(*SessionAuth); ok {
+		session, err = sessionAuth._authenticate(authReq)
+	} else {
+		err = t.auth.Authenticate(authReq)
+	}
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, 0, fmt.Errorf("error authenticating request: %w", err)
+	}
+
+	resp, err := t.base.RoundTrip(authReq)
+	return resp, session, err
+}
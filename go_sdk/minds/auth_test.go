@@ -0,0 +1,166 @@
+package minds
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// sessionServer accepts the cookie of its latest login.
+type sessionServer struct {
+	mu      sync.Mutex
+	logins  int
+	current string
+}
+
+func (s *sessionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.URL.Path == "/api/login" {
+		s.logins++
+		s.current = strconv.Itoa(s.logins)
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: s.current, Path: "/"})
+		return
+	}
+	cookie, err := r.Cookie("session")
+	if err != nil || cookie.Value != s.current {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Write([]byte(`[]`))
+}
+
+func (s *sessionServer) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = ""
+}
+
+func TestSessionAuthSharesLogins(t *testing.T) {
+	server := &sessionServer{}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	client := NewClient("", srv.URL, WithAuthenticator(NewSessionAuth(srv.URL, "admin", "secret")))
+	listConcurrently := func() {
+		var wg sync.WaitGroup
+		var failures int32
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.Skills.List(); err != nil {
+					atomic.AddInt32(&failures, 1)
+				}
+			}()
+		}
+		wg.Wait()
+		if failures != 0 {
+			t.Fatalf("%d requests failed", failures)
+		}
+	}
+
+	listConcurrently()
+	if server.logins != 1 {
+		t.Errorf("logins after first requests = %d, want 1", server.logins)
+	}
+
+	server.expire()
+	listConcurrently()
+	if server.logins != 2 {
+		t.Errorf("logins after session expiry = %d, want 2", server.logins)
+	}
+}
+
+func TestSessionAuthHosts(t *testing.T) {
+	primary := &sessionServer{}
+	srvA := httptest.NewServer(primary)
+	defer srvA.Close()
+	secondary := &sessionServer{}
+	srvB := httptest.NewServer(secondary)
+	defer srvB.Close()
+
+	pool, err := NewEndpointPool(Endpoint{BaseURL: srvA.URL}, Endpoint{BaseURL: srvB.URL, LLMURL: "http://llm.b.test/v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := NewSessionAuth(srvA.URL, "admin", "secret")
+	NewRestAPI("", srvA.URL, WithEndpoints(pool), WithAuthenticator(auth))
+
+	hostA := strings.TrimPrefix(srvA.URL, "http://")
+	hostB := strings.TrimPrefix(srvB.URL, "http://")
+	tests := []struct {
+		url        string
+		wantCookie string
+	}{
+		{"http://" + hostA + "/api/minds", "session=1"},
+		// The completion endpoint shares the session of its deployment.
+		{"http://ai." + hostA + "/chat/completions", "session=1"},
+		// Other deployments of the pool log in separately.
+		{"http://" + hostB + "/api/minds", "session=1"},
+		{"http://llm.b.test/v1/chat/completions", "session=1"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.url, nil)
+		if err := auth.Authenticate(req); err != nil {
+			t.Errorf("Authenticate(%s) error = %v", tt.url, err)
+			continue
+		}
+		if got := req.Header.Get("Cookie"); got != tt.wantCookie {
+			t.Errorf("Authenticate(%s) cookie = %q, want %q", tt.url, got, tt.wantCookie)
+		}
+	}
+	if primary.logins != 1 || secondary.logins != 1 {
+		t.Errorf("logins = %d and %d, want one per deployment", primary.logins, secondary.logins)
+	}
+
+	var invalid *InvalidConfig
+	req := httptest.NewRequest("GET", "http://elsewhere.test/api/minds", nil)
+	if err := auth.Authenticate(req); !errors.As(err, &invalid) {
+		t.Errorf("Authenticate() error = %v, want InvalidConfig", err)
+	}
+}
+
+func TestSessionAuthLoginOutlivesCancelledCaller(t *testing.T) {
+	release := make(chan struct{})
+	var logins int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&logins, 1)
+		<-release
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "1", Path: "/"})
+	}))
+	defer srv.Close()
+	auth := NewSessionAuth(srv.URL, "admin", "secret")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		first <- auth.Authenticate(httptest.NewRequest("GET", srv.URL+"/api/minds", nil).WithContext(ctx))
+	}()
+	for atomic.LoadInt32(&logins) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	second := make(chan error)
+	go func() {
+		second <- auth.Authenticate(httptest.NewRequest("GET", srv.URL+"/api/minds", nil))
+	}()
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller error = %v, want context.Canceled", err)
+	}
+	close(release)
+	if err := <-second; err != nil {
+		t.Errorf("waiting caller error = %v, want the shared login to succeed", err)
+	}
+	if logins != 1 {
+		t.Errorf("logins = %d, want 1", logins)
+	}
+}
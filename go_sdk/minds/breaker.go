@@ -0,0 +1,268 @@
+package minds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails requests fast without sending them.
+	CircuitOpen
+	// CircuitHalfOpen lets probe requests through to test whether the
+	// service has recovered.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("CircuitState(%d)", int(s))
+	}
+}
+
+// CircuitBreaker stops sending requests to a failing service. Transport
+// errors and 5xx responses count as failures. After FailureThreshold
+// consecutive failures the circuit opens, and requests fail with
+// CircuitOpenError until OpenTimeout has passed. A single probe request is
+// then let through; SuccessThreshold successful probes close the circuit,
+// and any failed probe opens it again.
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit. It defaults to 5.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before probing.
+	// It defaults to 30 seconds.
+	OpenTimeout time.Duration
+	// SuccessThreshold is the number of successful probes that closes the
+	// circuit. It defaults to 1.
+	SuccessThreshold int
+	// PerEndpoint keeps a separate circuit for each host, so that the REST
+	// API and the completion endpoint trip independently.
+	PerEndpoint bool
+	// OnStateChange, if set, is called when a circuit changes state. endpoint
+	// is the host, or "" for the global circuit. It is called after the
+	// breaker is unlocked, so it may call the breaker's methods.
+	OnStateChange func(endpoint string, from CircuitState, to CircuitState)
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// circuitTransition is a change of circuit state, reported to
+// OnStateChange once the breaker is unlocked.
+type circuitTransition struct {
+	endpoint string
+	from     CircuitState
+	to       CircuitState
+}
+
+type circuit struct {
+	state     CircuitState
+	failures  int
+	successes int
+	openedAt  time.Time
+	probing   bool
+}
+
+// NewCircuitBreaker creates a global CircuitBreaker opening after
+// failureThreshold consecutive failures for openTimeout.
+func NewCircuitBreaker(failureThreshold int, openTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		FailureThreshold: failureThreshold,
+		OpenTimeout:      openTimeout,
+	}
+}
+
+// WithCircuitBreaker guards the client's REST and completion requests with breaker.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(r *RestAPI) {
+		r.CircuitBreaker = breaker
+	}
+}
+
+// State returns the state of the circuit for endpoint, or of the global
+// circuit when the breaker is not per endpoint.
+func (b *CircuitBreaker) State(endpoint string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b._circuit(endpoint)
+	if c.state == CircuitOpen && time.Since(c.openedAt) >= b._openTimeout() {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// _allow reports whether a request to endpoint may be sent. A true probe
+// result means the request is the half-open probe.
+func (b *CircuitBreaker) _allow(endpoint string) (allowed bool, probe bool) {
+	var changed *circuitTransition
+	b.mu.Lock()
+	defer func() {
+		b.mu.Unlock()
+		b._notify(changed)
+	}()
+
+	c := b._circuit(endpoint)
+	if c.state == CircuitOpen {
+		if time.Since(c.openedAt) < b._openTimeout() {
+			return false, false
+		}
+		changed = b._setState(endpoint, c, CircuitHalfOpen)
+	}
+	if c.state == CircuitHalfOpen {
+		if c.probing {
+			return false, false
+		}
+		c.probing = true
+		return true, true
+	}
+	return true, false
+}
+
+// _record updates the circuit for endpoint with the outcome of a request.
+func (b *CircuitBreaker) _record(endpoint string, probe bool, failed bool) {
+	var changed *circuitTransition
+	b.mu.Lock()
+	defer func() {
+		b.mu.Unlock()
+		b._notify(changed)
+	}()
+
+	c := b._circuit(endpoint)
+	if probe {
+		c.probing = false
+	}
+	switch c.state {
+	case CircuitClosed:
+		if !failed {
+			c.failures = 0
+			return
+		}
+		c.failures++
+		if c.failures >= b._failureThreshold() {
+			c.openedAt = time.Now()
+			changed = b._setState(endpoint, c, CircuitOpen)
+		}
+	case CircuitHalfOpen:
+		if failed {
+			c.openedAt = time.Now()
+			changed = b._setState(endpoint, c, CircuitOpen)
+			return
+		}
+		c.successes++
+		if c.successes >= b._successThreshold() {
+			changed = b._setState(endpoint, c, CircuitClosed)
+		}
+	}
+}
+
+// _abandonProbe lets another request probe the circuit for endpoint.
+func (b *CircuitBreaker) _abandonProbe(endpoint string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b._circuit(endpoint).probing = false
+}
+
+// _setState moves c to state and returns the transition to report. The
+// breaker must be locked.
+func (b *CircuitBreaker) _setState(endpoint string, c *circuit, state CircuitState) *circuitTransition {
+	from := c.state
+	c.state = state
+	c.failures = 0
+	c.successes = 0
+	return &circuitTransition{endpoint: endpoint, from: from, to: state}
+}
+
+// _notify reports a transition to OnStateChange. The breaker must not be
+// locked.
+func (b *CircuitBreaker) _notify(changed *circuitTransition) {
+	if changed != nil && b.OnStateChange != nil {
+		b.OnStateChange(changed.endpoint, changed.from, changed.to)
+	}
+}
+
+func (b *CircuitBreaker) _circuit(endpoint string) *circuit {
+	if !b.PerEndpoint {
+		endpoint = ""
+	}
+	if b.circuits == nil {
+		b.circuits = make(map[string]*circuit)
+	}
+	c, ok := b.circuits[endpoint]
+	if !ok {
+		c = &circuit{}
+		b.circuits[endpoint] = c
+	}
+	return c
+}
+
+func (b *CircuitBreaker) _failureThreshold() int {
+	if b.FailureThreshold <= 0 {
+		return 5
+	}
+	return b.FailureThreshold
+}
+
+func (b *CircuitBreaker) _successThreshold() int {
+	if b.SuccessThreshold <= 0 {
+		return 1
+	}
+	return b.SuccessThreshold
+}
+
+func (b *CircuitBreaker) _openTimeout() time.Duration {
+	if b.OpenTimeout <= 0 {
+		return 30 * time.Second
+	}
+	return b.OpenTimeout
+}
+
+// circuitBreakerTransport fails requests fast while their circuit is open.
+type circuitBreakerTransport struct {
+	api     *RestAPI
+	breaker *CircuitBreaker
+	base    http.RoundTripper
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := ""
+	if t.breaker.PerEndpoint {
+		endpoint = req.URL.Host
+	}
+	allowed, probe := t.breaker._allow(endpoint)
+	if !allowed {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		t.api._log(LevelDebug, "circuit open, failing fast", "method", req.Method, "path", _apiPath(req.URL.Path), "endpoint", endpoint)
+		return nil, &CircuitOpenError{Message: fmt.Sprintf("%s %s", req.Method, req.URL.Host)}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	// A request cancelled by the caller says nothing about the service.
+	cancelled := err != nil && errors.Is(req.Context().Err(), context.Canceled)
+	if !cancelled {
+		failed := (err != nil && !_isRateLimited(err)) || (err == nil && resp.StatusCode >= http.StatusInternalServerError)
+		t.breaker._record(endpoint, probe, failed)
+	} else if probe {
+		t.breaker._abandonProbe(endpoint)
+	}
+	return resp, err
+}
@@ -0,0 +1,134 @@
+package minds
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerStateMachine(t *testing.T) {
+	type step struct {
+		failed      bool
+		wantAllowed bool
+		wantState   CircuitState
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "opens after consecutive failures",
+			steps: []step{
+				{true, true, CircuitClosed},
+				{true, true, CircuitOpen},
+				{false, false, CircuitOpen},
+			},
+		},
+		{
+			name: "success resets the failure count",
+			steps: []step{
+				{true, true, CircuitClosed},
+				{false, true, CircuitClosed},
+				{true, true, CircuitClosed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := &CircuitBreaker{FailureThreshold: 2, OpenTimeout: time.Hour}
+			for i, s := range tt.steps {
+				allowed, probe := breaker._allow("")
+				if allowed != s.wantAllowed {
+					t.Fatalf("step %d: allowed = %v, want %v", i, allowed, s.wantAllowed)
+				}
+				if allowed {
+					breaker._record("", probe, s.failed)
+				}
+				if state := breaker.State(""); state != s.wantState {
+					t.Fatalf("step %d: state = %v, want %v", i, state, s.wantState)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerProbes(t *testing.T) {
+	tests := []struct {
+		name        string
+		probeFailed bool
+		wantState   CircuitState
+	}{
+		{"successful probe closes", false, CircuitClosed},
+		{"failed probe reopens", true, CircuitOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := &CircuitBreaker{FailureThreshold: 1, OpenTimeout: time.Millisecond}
+			breaker._record("", false, true)
+			time.Sleep(2 * time.Millisecond)
+
+			allowed, probe := breaker._allow("")
+			if !allowed || !probe {
+				t.Fatalf("_allow() = %v, %v; want a probe", allowed, probe)
+			}
+			if allowed, _ := breaker._allow(""); allowed {
+				t.Fatal("a second request was let through while probing")
+			}
+			breaker._record("", true, tt.probeFailed)
+			breaker.OpenTimeout = time.Hour
+			if state := breaker.State(""); state != tt.wantState {
+				t.Errorf("state = %v, want %v", state, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerOnStateChangeMayUseBreaker(t *testing.T) {
+	var transitions [][2]CircuitState
+	breaker := &CircuitBreaker{FailureThreshold: 1, OpenTimeout: time.Millisecond}
+	breaker.OnStateChange = func(endpoint string, from CircuitState, to CircuitState) {
+		// This deadlocks if the callback runs with the breaker locked.
+		if state := breaker.State(endpoint); state != to {
+			t.Errorf("State() in callback = %v, want %v", state, to)
+		}
+		transitions = append(transitions, [2]CircuitState{from, to})
+	}
+
+	breaker._record("", false, true)
+	time.Sleep(2 * time.Millisecond)
+	_, probe := breaker._allow("")
+	breaker._record("", probe, false)
+
+	want := [][2]CircuitState{
+		{CircuitClosed, CircuitOpen},
+		{CircuitOpen, CircuitHalfOpen},
+		{CircuitHalfOpen, CircuitClosed},
+	}
+	if !reflect.DeepEqual(transitions, want) {
+		t.Errorf("transitions = %v, want %v", transitions, want)
+	}
+}
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", srv.URL, WithCircuitBreaker(NewCircuitBreaker(2, time.Hour)))
+	for i := 0; i < 3; i++ {
+		client.Skills.List()
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	var open *CircuitOpenError
+	if _, err := client.Skills.List(); !errors.As(err, &open) {
+		t.Errorf("List() error = %v, want CircuitOpenError", err)
+	}
+}
@@ -0,0 +1,298 @@
+package minds
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// CassetteMode selects whether a Recorder records or replays traffic.
+type CassetteMode int
+
+const (
+	// CassetteReplay answers requests from the cassette without touching
+	// the network.
+	CassetteReplay CassetteMode = iota
+	// CassetteRecord sends requests and records them; Close saves them to
+	// the cassette, replacing its previous content.
+	CassetteRecord
+	// CassetteAuto replays the cassette if its file exists and records it
+	// otherwise.
+	CassetteAuto
+)
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request as stored in a cassette.
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	Path    string      `json:"path"`
+	Query   string      `json:"query,omitempty"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response as stored in a cassette. Streamed (SSE)
+// responses are stored whole and replayed in one piece.
+type RecordedResponse struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body"`
+}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper recording REST and completion traffic to
+// a JSON cassette file, or replaying it offline. Credential headers and
+// secret JSON fields and query parameters are scrubbed before they are
+// saved. Requests are matched on method, path, query and body; each recorded
+// interaction is replayed once, in order. Pass the recorder to WithTransport,
+// and Close it to write a recorded cassette.
+type Recorder struct {
+	// Base sends requests while recording. It defaults to
+	// http.DefaultTransport.
+	Base http.RoundTripper
+
+	path     string
+	mode     CassetteMode
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewRecorder creates a Recorder for the cassette file at path.
+func NewRecorder(path string, mode CassetteMode) (*Recorder, error) {
+	if mode == CassetteAuto {
+		mode = CassetteRecord
+		if _, err := os.Stat(path); err == nil {
+			mode = CassetteReplay
+		}
+	}
+
+	r := &Recorder{path: path, mode: mode, cassette: &Cassette{}}
+	if mode == CassetteReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("error decoding cassette: %w", err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns whether the recorder is recording or replaying.
+func (r *Recorder) Mode() CassetteMode {
+	return r.mode
+}
+
+// RoundTrip records or replays req.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := _readRequestBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+	recorded := RecordedRequest{
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Query:   _scrubQuery(req.URL.RawQuery),
+		Headers: _redactHeaders(req.Header),
+		Body:    _scrubBody(req.Header.Get("Content-Type"), body),
+	}
+
+	if r.mode == CassetteReplay {
+		return r._replay(req, recorded)
+	}
+	return r._record(req, recorded, body)
+}
+
+// Save writes the interactions recorded so far to the cassette file,
+// replacing it.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r._save()
+}
+
+// Close writes the cassette file when recording. Replaying recorders have
+// nothing to write.
+func (r *Recorder) Close() error {
+	if r.mode != CassetteRecord {
+		return nil
+	}
+	return r.Save()
+}
+
+func (r *Recorder) _record(req *http.Request, recorded RecordedRequest, body []byte) (*http.Response, error) {
+	base := r.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if body != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	headers := _redactHeaders(resp.Header)
+	// Scrubbing can change the body's length.
+	headers.Del("Content-Length")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status:  resp.StatusCode,
+			Headers: headers,
+			Body:    _scrubBody(resp.Header.Get("Content-Type"), respBody),
+		},
+	})
+	return resp, nil
+}
+
+func (r *Recorder) _replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !_matchRequest(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+		response := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", response.Status, http.StatusText(response.Status)),
+			StatusCode:    response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(response.Body)),
+			ContentLength: int64(len(response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded interaction for %s %s", req.Method, _redactURL(req.URL))
+}
+
+// _save writes the cassette to a temporary file and renames it over the
+// cassette, so that a crash never leaves a truncated cassette behind.
+func (r *Recorder) _save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding cassette: %w", err)
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("error writing cassette: %w", err)
+	}
+	if err := os.Rename(tmp, r.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("error writing cassette: %w", err)
+	}
+	return nil
+}
+
+// _matchRequest reports whether a recorded request matches a new one.
+// Multipart bodies are not compared because their boundaries are random.
+func _matchRequest(recorded RecordedRequest, req RecordedRequest) bool {
+	if recorded.Method != req.Method || recorded.Path != req.Path || recorded.Query != req.Query {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(req.Headers.Get("Content-Type"))
+	if strings.HasPrefix(mediaType, "multipart/") {
+		return true
+	}
+	return recorded.Body == req.Body
+}
+
+// _scrubBody redacts secrets from JSON bodies and canonicalizes them so
+// that key order does not affect matching.
+func _scrubBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "text/event-stream" {
+		return _scrubEvents(body)
+	}
+	if mediaType == "application/json" || json.Valid(body) {
+		return _redactJSON(body)
+	}
+	return string(body)
+}
+
+// _scrubEvents redacts secrets from the JSON payload of each data line of
+// a server-sent event stream, keeping every other line as it is.
+func _scrubEvents(body []byte) string {
+	lines := strings.SplitAfter(string(body), "\n")
+	for i, line := range lines {
+		content := strings.TrimRight(line, "\r\n")
+		if !strings.HasPrefix(content, "data:") {
+			continue
+		}
+		payload := strings.TrimPrefix(strings.TrimPrefix(content, "data:"), " ")
+		if !json.Valid([]byte(payload)) {
+			continue
+		}
+		lines[i] = "data: " + _redactJSON([]byte(payload)) + line[len(content):]
+	}
+	return strings.Join(lines, "")
+}
+
+// _scrubQuery redacts secret query parameters and sorts the rest, so that
+// parameter order does not affect matching.
+func _scrubQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return rawQuery
+	}
+	for key := range query {
+		if _isSecretField(key) {
+			query[key] = []string{"REDACTED"}
+		}
+	}
+	return query.Encode()
+}
+
+func _readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	body, err := io.ReadAll(req.Body)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,132 @@
+package minds
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"name": "s", "type": "text2sql", "params": {"api_key": "sk-live"}}]`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "skills.json")
+	recorder, err := NewRecorder(path, CassetteAuto)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	if recorder.Mode() != CassetteRecord {
+		t.Fatalf("Mode() = %v, want CassetteRecord", recorder.Mode())
+	}
+	if _, err := NewClient("secret-key", srv.URL, WithTransport(recorder)).Skills.List(); err != nil {
+		t.Fatalf("List() while recording error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("cassette written before Close: %v", err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, secret := range []string{"secret-key", "sk-live"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	srv.Close()
+	replayer, err := NewRecorder(path, CassetteAuto)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	if replayer.Mode() != CassetteReplay {
+		t.Fatalf("Mode() = %v, want CassetteReplay", replayer.Mode())
+	}
+	skills, err := NewClient("other-key", srv.URL, WithTransport(replayer)).Skills.List()
+	if err != nil {
+		t.Fatalf("List() while replaying error = %v", err)
+	}
+	if len(skills) != 1 || skills[0].Name != "s" {
+		t.Errorf("List() = %+v", skills)
+	}
+}
+
+func TestMatchRequest(t *testing.T) {
+	recorded := RecordedRequest{Method: "GET", Path: "/api/files", Query: "limit=10&offset=0", Body: `{"a":1}`}
+	tests := []struct {
+		name string
+		req  RecordedRequest
+		want bool
+	}{
+		{"same", recorded, true},
+		{"other method", RecordedRequest{Method: "POST", Path: "/api/files", Query: "limit=10&offset=0", Body: `{"a":1}`}, false},
+		{"other path", RecordedRequest{Method: "GET", Path: "/api/minds", Query: "limit=10&offset=0", Body: `{"a":1}`}, false},
+		{"other query", RecordedRequest{Method: "GET", Path: "/api/files", Query: "limit=10&offset=10", Body: `{"a":1}`}, false},
+		{"other body", RecordedRequest{Method: "GET", Path: "/api/files", Query: "limit=10&offset=0", Body: `{"a":2}`}, false},
+		{
+			"multipart ignores body",
+			RecordedRequest{
+				Method:  "GET",
+				Path:    "/api/files",
+				Query:   "limit=10&offset=0",
+				Headers: http.Header{"Content-Type": {"multipart/form-data; boundary=x"}},
+				Body:    "--x--",
+			},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := _matchRequest(recorded, tt.req); got != tt.want {
+				t.Errorf("_matchRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScrubQuery(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"", ""},
+		{"offset=0&limit=10", "limit=10&offset=0"},
+		{"token=abc&name=x", "name=x&token=REDACTED"},
+		{"refresh_tokens=r&max_tokens=5", "max_tokens=5&refresh_tokens=REDACTED"},
+	}
+	for _, tt := range tests {
+		if got := _scrubQuery(tt.raw); got != tt.want {
+			t.Errorf("_scrubQuery(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestScrubBody(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		want        string
+	}{
+		{"application/json", `{"b":1,"password":"p"}`, `{"b":1,"password":"REDACTED"}`},
+		{"", `{"z":1,"a":2}`, `{"a":2,"z":1}`},
+		{"text/plain", "hello", "hello"},
+		{"application/json", "", ""},
+		{
+			"text/event-stream",
+			"event: chunk\ndata: {\"content\":\"hi\",\"api_key\":\"k\"}\n\ndata: {\"usage\":{\"total_tokens\":3}}\r\n\r\ndata: [DONE]\n\n",
+			"event: chunk\ndata: {\"api_key\":\"REDACTED\",\"content\":\"hi\"}\n\ndata: {\"usage\":{\"total_tokens\":3}}\r\n\r\ndata: [DONE]\n\n",
+		},
+	}
+	for _, tt := range tests {
+		if got := _scrubBody(tt.contentType, []byte(tt.body)); got != tt.want {
+			t.Errorf("_scrubBody(%q, %q) = %q, want %q", tt.contentType, tt.body, got, tt.want)
+		}
+	}
+}
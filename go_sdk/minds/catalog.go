@@ -0,0 +1,64 @@
+package minds
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// catalogTTL is how long a fetched catalog is used before it is
+	// fetched again.
+	catalogTTL = 10 * time.Minute
+	// catalogRetryAfter is how long validation is skipped after a catalog
+	// could not be fetched.
+	catalogRetryAfter = time.Minute
+)
+
+// catalog lazily fetches and caches server metadata used for client-side
+// validation. Validation is best effort: whenever the catalog cannot be
+// fetched, whether the endpoint is missing, forbidden or failing, callers
+// skip validation instead of failing the operation.
+type catalog[T any] struct {
+	api   *RestAPI
+	name  string
+	fetch func(ctx context.Context, api *RestAPI) (T, error)
+
+	mu        sync.Mutex
+	value     T
+	available bool
+	expires   time.Time
+}
+
+// set stores a catalog fetched elsewhere, such as by Client.Handlers.
+func (c *catalog[T]) set(value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value, c.available = value, true
+	c.expires = time.Now().Add(catalogTTL)
+}
+
+// get returns the cached catalog, fetching it when it is missing or expired.
+// It returns false when no catalog is available and validation should be
+// skipped.
+func (c *catalog[T]) get(ctx context.Context) (T, bool) {
+	c.mu.Lock()
+	if time.Now().Before(c.expires) {
+		defer c.mu.Unlock()
+		return c.value, c.available
+	}
+	c.mu.Unlock()
+
+	value, err := c.fetch(ctx, c.api)
+	if err != nil {
+		c.api._log(LevelWarn, "catalog unavailable, skipping validation", "catalog", c.name, "error", err)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		var zero T
+		c.value, c.available = zero, false
+		c.expires = time.Now().Add(catalogRetryAfter)
+		return zero, false
+	}
+	c.set(value)
+	return value, true
+}
@@ -0,0 +1,269 @@
+package minds
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// CHAOS_ENV is the environment variable read by ChaosFromEnv.
+const CHAOS_ENV = "MINDSDB_CHAOS"
+
+// Fault describes a failure injected by a ChaosTransport.
+type Fault struct {
+	// Method restricts the fault to one HTTP method; empty matches all.
+	Method string
+	// Path restricts the fault to API paths (as in /minds/{name}, without
+	// the /api prefix) matching this path.Match pattern. A trailing "*"
+	// also matches deeper paths. Empty matches all.
+	Path string
+	// Probability is the chance, between 0 and 1, that a matching request
+	// is affected. Zero means never, so a fault applied to every request
+	// needs a probability of 1.
+	Probability float64
+
+	// Latency delays the request.
+	Latency time.Duration
+	// Reset fails the request as if the connection had been reset.
+	Reset bool
+	// Status answers the request with this status code without sending it.
+	Status int
+	// TruncateAfter cuts the response body after this many bytes.
+	TruncateAfter int
+	// BreakStreamAfter cuts a server-sent event stream after this many events.
+	BreakStreamAfter int
+}
+
+// ChaosTransport is an http.RoundTripper that injects faults into requests,
+// to exercise retry and fallback logic. Every matching fault is applied in
+// order; a reset or status fault ends the request. Pass it to WithTransport.
+type ChaosTransport struct {
+	// Base sends requests. It defaults to http.DefaultTransport.
+	Base   http.RoundTripper
+	Faults []Fault
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewChaosTransport creates a ChaosTransport injecting faults into requests
+// sent with base.
+func NewChaosTransport(base http.RoundTripper, faults ...Fault) *ChaosTransport {
+	return &ChaosTransport{
+		Base:   base,
+		Faults: faults,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// ChaosFromEnv creates a ChaosTransport from the MINDSDB_CHAOS environment
+// variable. When it is unset the transport injects no faults and passes
+// requests to base. Faults are separated by ";" and their settings by ",";
+// a fault without a probability (p) applies to every matching request. For
+// example:
+//
+//	latency=200ms,p=0.5;status=503,path=/minds/*,method=GET;reset,p=0.1;break_stream=3
+func ChaosFromEnv(base http.RoundTripper) (*ChaosTransport, error) {
+	spec := os.Getenv(CHAOS_ENV)
+	if spec == "" {
+		return NewChaosTransport(base), nil
+	}
+	faults, err := ParseFaults(spec)
+	if err != nil {
+		return nil, err
+	}
+	return NewChaosTransport(base, faults...), nil
+}
+
+// ParseFaults parses faults in the format read by ChaosFromEnv.
+func ParseFaults(spec string) ([]Fault, error) {
+	var faults []Fault
+	for _, rule := range strings.Split(spec, ";") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+		fault := Fault{Probability: 1}
+		for _, setting := range strings.Split(rule, ",") {
+			key, value, hasValue := strings.Cut(strings.TrimSpace(setting), "=")
+			var err error
+			switch key {
+			case "method":
+				fault.Method = strings.ToUpper(value)
+			case "path":
+				fault.Path = value
+			case "p":
+				fault.Probability, err = strconv.ParseFloat(value, 64)
+				if err == nil && (fault.Probability < 0 || fault.Probability > 1) {
+					err = fmt.Errorf("probability must be between 0 and 1")
+				}
+			case "latency":
+				fault.Latency, err = time.ParseDuration(value)
+			case "reset":
+				fault.Reset = true
+				if hasValue {
+					fault.Reset, err = strconv.ParseBool(value)
+				}
+			case "status":
+				fault.Status, err = strconv.Atoi(value)
+			case "truncate":
+				fault.TruncateAfter, err = strconv.Atoi(value)
+			case "break_stream":
+				fault.BreakStreamAfter, err = strconv.Atoi(value)
+			default:
+				err = fmt.Errorf("unknown setting")
+			}
+			if err != nil {
+				return nil, &InvalidConfig{Message: fmt.Sprintf("invalid fault setting %q: %v", setting, err)}
+			}
+		}
+		faults = append(faults, fault)
+	}
+	return faults, nil
+}
+
+// RoundTrip sends req, injecting the faults that apply to it.
+func (t *ChaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var truncateAfter, breakStreamAfter int
+	for _, fault := range t.Faults {
+		if !fault._matches(req) || !t._roll(fault.Probability) {
+			continue
+		}
+		if fault.Latency > 0 {
+			if err := _sleep(req.Context(), fault.Latency); err != nil {
+				return nil, err
+			}
+		}
+		if fault.Reset || fault.Status != 0 {
+			if req.Body != nil {
+				req.Body.Close()
+			}
+		}
+		if fault.Reset {
+			return nil, fmt.Errorf("injected fault: %w", syscall.ECONNRESET)
+		}
+		if fault.Status != 0 {
+			body := fmt.Sprintf(`{"detail": "injected fault: %d"}`, fault.Status)
+			return &http.Response{
+				Status:        fmt.Sprintf("%d %s", fault.Status, http.StatusText(fault.Status)),
+				StatusCode:    fault.Status,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        http.Header{"Content-Type": {"application/json"}},
+				Body:          io.NopCloser(strings.NewReader(body)),
+				ContentLength: int64(len(body)),
+				Request:       req,
+			}, nil
+		}
+		if fault.TruncateAfter > 0 {
+			truncateAfter = fault.TruncateAfter
+		}
+		if fault.BreakStreamAfter > 0 {
+			breakStreamAfter = fault.BreakStreamAfter
+		}
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if truncateAfter > 0 || breakStreamAfter > 0 {
+		resp.Body = &brokenBody{ReadCloser: resp.Body, bytesLeft: truncateAfter, eventsLeft: breakStreamAfter}
+		resp.ContentLength = -1
+	}
+	return resp, nil
+}
+
+func (t *ChaosTransport) _roll(probability float64) bool {
+	if probability <= 0 {
+		return false
+	}
+	if probability >= 1 {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.rand == nil {
+		t.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return t.rand.Float64() < probability
+}
+
+func (f *Fault) _matches(req *http.Request) bool {
+	if f.Method != "" && !strings.EqualFold(f.Method, req.Method) {
+		return false
+	}
+	if f.Path == "" {
+		return true
+	}
+	apiPath := _apiPath(req.URL.Path)
+	if ok, _ := path.Match(f.Path, apiPath); ok {
+		return true
+	}
+	return strings.HasSuffix(f.Path, "*") && strings.HasPrefix(apiPath, strings.TrimSuffix(f.Path, "*"))
+}
+
+// brokenBody ends a response body early with io.ErrUnexpectedEOF, after a
+// number of bytes or of server-sent events.
+type brokenBody struct {
+	io.ReadCloser
+	bytesLeft  int
+	eventsLeft int
+	// blankLine is set while the current line has held nothing but "\r".
+	blankLine bool
+	broken    bool
+}
+
+func (b *brokenBody) Read(p []byte) (int, error) {
+	if b.broken {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if b.bytesLeft > 0 && len(p) > b.bytesLeft {
+		p = p[:b.bytesLeft]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if b.eventsLeft > 0 {
+		for i := 0; i < n; i++ {
+			// Events end with a blank line, and lines end with "\n" or "\r\n".
+			switch p[i] {
+			case '\r':
+			case '\n':
+				if b.blankLine {
+					b.eventsLeft--
+					if b.eventsLeft == 0 {
+						n = i + 1
+						b.broken = true
+					}
+				}
+				b.blankLine = true
+			default:
+				b.blankLine = false
+			}
+			if b.broken {
+				break
+			}
+		}
+	}
+	if b.bytesLeft > 0 {
+		b.bytesLeft -= n
+		if b.bytesLeft == 0 {
+			b.broken = true
+		}
+	}
+	if b.broken && err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
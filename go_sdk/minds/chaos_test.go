@@ -0,0 +1,166 @@
+package minds
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestParseFaults(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []Fault
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"status=503", []Fault{{Probability: 1, Status: 503}}, false},
+		{
+			"latency=200ms,p=0.5; status=503,path=/minds/*,method=get;reset,p=0",
+			[]Fault{
+				{Probability: 0.5, Latency: 200 * time.Millisecond},
+				{Probability: 1, Status: 503, Path: "/minds/*", Method: "GET"},
+				{Probability: 0, Reset: true},
+			},
+			false,
+		},
+		{"truncate=10;break_stream=3", []Fault{{Probability: 1, TruncateAfter: 10}, {Probability: 1, BreakStreamAfter: 3}}, false},
+		{"reset=true;reset=false", []Fault{{Probability: 1, Reset: true}, {Probability: 1}}, false},
+		{"reset=maybe", nil, true},
+		{"status=abc", nil, true},
+		{"p=1.5", nil, true},
+		{"latency=soon", nil, true},
+		{"explode", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseFaults(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFaults() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFaults() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBrokenBodyEvents(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"lf", "data: 1\n\ndata: 2\n\ndata: 3\n\n", "data: 1\n\ndata: 2\n\n"},
+		{"crlf", "data: 1\r\n\r\ndata: 2\r\n\r\ndata: 3\r\n\r\n", "data: 1\r\n\r\ndata: 2\r\n\r\n"},
+		{"multi-line event", "event: a\r\ndata: 1\r\n\r\ndata: 2\n\ndata: 3\n\n", "event: a\r\ndata: 1\r\n\r\ndata: 2\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &brokenBody{ReadCloser: io.NopCloser(strings.NewReader(tt.body)), eventsLeft: 2}
+			got, err := io.ReadAll(body)
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Errorf("ReadAll() error = %v, want %v", err, io.ErrUnexpectedEOF)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ReadAll() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChaosRoll(t *testing.T) {
+	transport := NewChaosTransport(nil)
+	for _, tt := range []struct {
+		probability float64
+		want        bool
+	}{{0, false}, {-1, false}, {1, true}, {2, true}} {
+		if got := transport._roll(tt.probability); got != tt.want {
+			t.Errorf("_roll(%v) = %v, want %v", tt.probability, got, tt.want)
+		}
+	}
+}
+
+func TestFaultMatches(t *testing.T) {
+	tests := []struct {
+		fault Fault
+		want  bool
+	}{
+		{Fault{}, true},
+		{Fault{Method: "get"}, true},
+		{Fault{Method: "POST"}, false},
+		{Fault{Path: "/minds/*"}, true},
+		{Fault{Path: "/minds/m"}, true},
+		{Fault{Path: "/minds/*/completions"}, false},
+		{Fault{Path: "/files"}, false},
+	}
+	req := httptest.NewRequest("GET", "http://localhost/api/minds/m", nil)
+	for _, tt := range tests {
+		if got := tt.fault._matches(req); got != tt.want {
+			t.Errorf("%+v._matches() = %v, want %v", tt.fault, got, tt.want)
+		}
+	}
+}
+
+func TestChaosFromEnvUnsetPassesThrough(t *testing.T) {
+	t.Setenv(CHAOS_ENV, "")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	transport, err := ChaosFromEnv(nil)
+	if err != nil {
+		t.Fatalf("ChaosFromEnv() error = %v", err)
+	}
+	if _, err := NewClient("key", srv.URL, WithTransport(transport)).Skills.List(); err != nil {
+		t.Errorf("List() error = %v", err)
+	}
+}
+
+func TestChaosTransportFaults(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: 1\n\ndata: 2\n\ndata: 3\n\n"))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		fault    Fault
+		wantErr  error
+		wantBody string
+	}{
+		{"never", Fault{Status: 503}, nil, "data: 1\n\ndata: 2\n\ndata: 3\n\n"},
+		{"status", Fault{Status: 503, Probability: 1}, nil, `{"detail": "injected fault: 503"}`},
+		{"reset", Fault{Reset: true, Probability: 1}, syscall.ECONNRESET, ""},
+		{"truncate", Fault{TruncateAfter: 4, Probability: 1}, nil, "data"},
+		{"break stream", Fault{BreakStreamAfter: 2, Probability: 1}, nil, "data: 1\n\ndata: 2\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := NewChaosTransport(nil, tt.fault)
+			req := httptest.NewRequest("GET", srv.URL+"/api/minds", nil)
+			req.RequestURI = ""
+			resp, err := transport.RoundTrip(req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("RoundTrip() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -1,24 +1,47 @@
 package minds
 
 // Client is the main entry point for interacting with the MindsDB API.
+//
+// Every method that calls the server has a variant ending in Context, such
+// as Minds.GetContext, whose ctx cancels the call and becomes the parent of
+// its trace span. The plain methods use context.Background().
 type Client struct {
-	api         *RestAPI
-	Datasources *Datasources
-	Minds       *Minds
+	api            *RestAPI
+	handlers       *handlerCatalog
+	providers      *providerCatalog
+	Agents         *Agents
+	Datasources    *Datasources
+	Files          *Files
+	Jobs           *Jobs
+	KnowledgeBases *KnowledgeBases
+	Minds          *Minds
+	Models         *Models
+	Skills         *Skills
+	Views          *Views
 }
 
 // NewClient creates a new MindsDB client.
-func NewClient(apiKey string, baseURL string) *Client {
+func NewClient(apiKey string, baseURL string, opts ...Option) *Client {
 	// Create RestAPI instance with default base URL if not provided.
-	api := NewRestAPI(apiKey, baseURL)
+	api := NewRestAPI(apiKey, baseURL, opts...)
 
 	client := &Client{
-		api: api,
+		api:       api,
+		handlers:  newHandlerCatalog(api),
+		providers: newProviderCatalog(api),
 	}
 
-	// Initialize Datasources and Minds with the client instance.
+	// Initialize the services with the client instance.
 	client.Datasources = NewDatasources(api)
+	client.Datasources.handlers = client.handlers
+	client.Files = NewFiles(api)
+	client.Jobs = NewJobs(client, "mindsdb")
+	client.KnowledgeBases = NewKnowledgeBases(client, "mindsdb")
 	client.Minds = NewMinds(client)
+	client.Models = NewModels(client, "mindsdb")
+	client.Skills = NewSkills(client, "mindsdb")
+	client.Views = NewViews(client, "mindsdb")
+	client.Agents = NewAgents(client, "mindsdb")
 
 	return client
 }
@@ -0,0 +1,203 @@
+package minds
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// _llmURL returns the base URL of the OpenAI-compatible endpoint serving minds.
+func (r *RestAPI) _llmURL() (string, error) {
+	if r.Endpoints != nil {
+		// Requests are moved to the active endpoint by the transport.
+		return r.Endpoints.endpoints[0].llmURL, nil
+	}
+	return _deriveLLMURL(r.BaseURL)
+}
+
+// _deriveLLMURL returns the OpenAI-compatible endpoint of the deployment
+// serving the REST API at baseURL.
+func _deriveLLMURL(baseURL string) (string, error) {
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("error parsing API base URL: %w", err)
+	}
+
+	var llmHost string
+	if parsedURL.Host == "mdb.ai" {
+		llmHost = "llm.mdb.ai"
+	} else {
+		llmHost = "ai." + parsedURL.Host
+	}
+
+	parsedURL.Host = llmHost
+	parsedURL.Path = ""
+	return parsedURL.String(), nil
+}
+
+// _complete sends a user message to model on the OpenAI-compatible endpoint
+// at baseURL. Streamed responses are accumulated into a single string.
+func _complete(ctx context.Context, api *RestAPI, baseURL string, model string, message string, useStream bool) (_ string, err error) {
+	call, err := _startCompletion(ctx, api, model, useStream)
+	if err != nil {
+		return "", err
+	}
+	defer func() { call._done(err) }()
+
+	// Credentials are added by the HTTP client's transport.
+	clientConfig := openai.DefaultConfig("")
+	clientConfig.BaseURL = baseURL
+	clientConfig.HTTPClient = api._httpClient()
+	openAIClient := openai.NewClientWithConfig(clientConfig)
+
+	messages := []openai.ChatCompletionMessage{{Role: "user", Content: message}}
+
+	var streamOptions *openai.StreamOptions
+	if api.Usage != nil {
+		// Usage is only sent at the end of a stream when requested.
+		streamOptions = &openai.StreamOptions{IncludeUsage: true}
+	}
+
+	if useStream {
+		stream, err := openAIClient.CreateChatCompletionStream(
+			ctx,
+			openai.ChatCompletionRequest{
+				Model:         model,
+				Messages:      messages,
+				Stream:        true,
+				StreamOptions: streamOptions,
+			},
+		)
+		if err != nil {
+			return "", fmt.Errorf("error creating chat completion stream: %w", err)
+		}
+		defer stream.Close()
+		api._log(LevelDebug, "completion stream opened", "model", model)
+
+		var fullResponse string
+		for {
+			response, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				api._log(LevelWarn, "completion stream failed", "model", model, "chunks", call.chunks, "error", err)
+				return "", fmt.Errorf("error receiving chat completion stream: %w", err)
+			}
+			if len(response.Choices) > 0 && response.Choices[0].Delta.Content != "" {
+				call._output()
+				fullResponse += response.Choices[0].Delta.Content
+			}
+			if response.Usage != nil {
+				_recordUsage(ctx, api, model, *response.Usage)
+			}
+		}
+		api._log(LevelDebug, "completion stream closed", "model", model, "chunks", call.chunks)
+		return fullResponse, nil
+	}
+
+	response, err := openAIClient.CreateChatCompletion(
+		ctx,
+		openai.ChatCompletionRequest{
+			Model:    model,
+			Messages: messages,
+			Stream:   false,
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("error creating chat completion: %w", err)
+	}
+	_recordUsage(ctx, api, model, response.Usage)
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("chat completion returned no choices")
+	}
+	return response.Choices[0].Message.Content, nil
+}
+
+// completionCall is a completion of a mind or an agent in progress. It holds
+// a completion slot and collects the metrics reported when it is done.
+type completionCall struct {
+	api              *RestAPI
+	model            string
+	stream           bool
+	start            time.Time
+	timeToFirstToken time.Duration
+	chunks           int
+}
+
+// _startCompletion checks the usage budget and waits for a completion slot
+// for model. The caller must call _done once the completion has finished.
+func _startCompletion(ctx context.Context, api *RestAPI, model string, stream bool) (*completionCall, error) {
+	if api.Usage != nil {
+		if err := api.Usage.Check(); err != nil {
+			return nil, err
+		}
+	}
+	if api.CompletionLimiter != nil {
+		if err := api.CompletionLimiter.Acquire(ctx, model); err != nil {
+			return nil, fmt.Errorf("error waiting for completion slot: %w", err)
+		}
+	}
+	return &completionCall{api: api, model: model, stream: stream, start: time.Now()}, nil
+}
+
+// _output records a streamed chunk that carries part of the answer. Chunks
+// without output, such as role headers or agent steps, are not counted.
+func (c *completionCall) _output() {
+	if c.chunks == 0 {
+		c.timeToFirstToken = time.Since(c.start)
+	}
+	c.chunks++
+}
+
+// _done releases the completion slot and reports the completion's metrics.
+func (c *completionCall) _done(err error) {
+	if c.api.CompletionLimiter != nil {
+		c.api.CompletionLimiter.Release(c.model)
+	}
+	if c.api.Metrics != nil {
+		c.api.Metrics.ObserveCompletion(c.model, c.stream, err != nil, c.timeToFirstToken, time.Since(c.start), c.chunks)
+	}
+}
+
+// _readEvents calls handle with the data of each server-sent event in body,
+// until handle is done or the stream ends with [DONE]. Mind completions are
+// streamed through the OpenAI client instead.
+func _readEvents(body io.Reader, handle func(data string) (done bool, err error)) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return nil
+		}
+		done, err := handle(data)
+		if err != nil || done {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// _recordUsage adds the usage of a completion by mind to the client's accountant.
+func _recordUsage(ctx context.Context, api *RestAPI, mind string, usage openai.Usage) {
+	if api.Usage == nil {
+		return
+	}
+	labels := _usageLabels(ctx)
+	cost := api.Usage.Record(mind, labels.model, labels.tag, Usage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+	})
+	api._log(LevelDebug, "completion usage recorded", "model", mind, "prompt_tokens", usage.PromptTokens, "completion_tokens", usage.CompletionTokens, "cost", cost)
+}
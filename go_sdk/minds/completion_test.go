@@ -0,0 +1,34 @@
+package minds
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompleteStreamCountsOutputChunks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte(
+			"data: {\"choices\": [{\"index\": 0, \"delta\": {\"role\": \"assistant\"}}]}\n\n" +
+				"data: {\"choices\": [{\"index\": 0, \"delta\": {\"content\": \"Hello\"}}]}\n\n" +
+				"data: {\"choices\": [{\"index\": 0, \"delta\": {\"content\": \" there\"}}]}\n\n" +
+				"data: [DONE]\n\n"))
+	}))
+	defer srv.Close()
+
+	metrics := &completionMetrics{}
+	client := NewClient("key", srv.URL, WithMetrics(metrics))
+	answer, err := _complete(context.Background(), client.api, srv.URL, "mind", "hi", true)
+	if err != nil {
+		t.Fatalf("_complete() error = %v", err)
+	}
+	if answer != "Hello there" {
+		t.Errorf("answer = %q", answer)
+	}
+	// The role header carries no output and is not counted.
+	if metrics.chunks != 2 || metrics.timeToFirstToken <= 0 {
+		t.Errorf("chunks = %d, timeToFirstToken = %v, want 2 chunks", metrics.chunks, metrics.timeToFirstToken)
+	}
+}
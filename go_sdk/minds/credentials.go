@@ -0,0 +1,185 @@
+package minds
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialProvider supplies the API key sent as a bearer token, allowing
+// keys to be rotated without recreating the client.
+type CredentialProvider interface {
+	// APIKey returns the current API key.
+	APIKey(ctx context.Context) (string, error)
+	// Refresh discards any cached key after the server rejected it.
+	Refresh(ctx context.Context) error
+}
+
+// WithCredentials authenticates requests with keys from provider. On a 401
+// response the provider is refreshed and the request retried once.
+func WithCredentials(provider CredentialProvider) Option {
+	return WithAuthenticator(&CredentialAuth{Provider: provider})
+}
+
+// CredentialAuth sends the key from a CredentialProvider as a bearer token.
+type CredentialAuth struct {
+	Provider CredentialProvider
+}
+
+// Authenticate sets the Authorization header to the provider's current key.
+func (a *CredentialAuth) Authenticate(req *http.Request) error {
+	apiKey, err := a.Provider.APIKey(req.Context())
+	if err != nil {
+		return fmt.Errorf("error getting API key: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	return nil
+}
+
+// Refresh refreshes the provider.
+func (a *CredentialAuth) Refresh(ctx context.Context) error {
+	return a.Provider.Refresh(ctx)
+}
+
+// EnvCredentials reads the API key from an environment variable on every
+// request.
+type EnvCredentials struct {
+	Name string
+}
+
+// APIKey returns the variable's value.
+func (c *EnvCredentials) APIKey(ctx context.Context) (string, error) {
+	apiKey := os.Getenv(c.Name)
+	if apiKey == "" {
+		return "", fmt.Errorf("%s environment variable not set", c.Name)
+	}
+	return apiKey, nil
+}
+
+// Refresh does nothing; the variable is read on every request.
+func (c *EnvCredentials) Refresh(ctx context.Context) error {
+	return nil
+}
+
+// FileCredentials reads the API key from a file, re-reading it whenever the
+// file's modification time changes. Surrounding whitespace is trimmed.
+type FileCredentials struct {
+	Path string
+
+	mu      sync.Mutex
+	apiKey  string
+	modTime time.Time
+}
+
+// APIKey returns the key, reloading the file if it changed.
+func (c *FileCredentials) APIKey(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	info, err := os.Stat(c.Path)
+	if err != nil {
+		return "", fmt.Errorf("error reading credentials file: %w", err)
+	}
+	if c.apiKey != "" && info.ModTime().Equal(c.modTime) {
+		return c.apiKey, nil
+	}
+
+	data, err := os.ReadFile(c.Path)
+	if err != nil {
+		return "", fmt.Errorf("error reading credentials file: %w", err)
+	}
+	apiKey := strings.TrimSpace(string(data))
+	if apiKey == "" {
+		return "", fmt.Errorf("credentials file %s is empty", c.Path)
+	}
+	c.apiKey = apiKey
+	c.modTime = info.ModTime()
+	return c.apiKey, nil
+}
+
+// Refresh forces the file to be read again on the next request.
+func (c *FileCredentials) Refresh(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apiKey = ""
+	return nil
+}
+
+// CallbackCredentials obtains the API key from a function, such as a
+// secrets manager lookup. The key is cached until the server rejects it.
+// Concurrent requests for a key share one call to Fetch.
+type CallbackCredentials struct {
+	Fetch func(ctx context.Context) (string, error)
+
+	mu     sync.Mutex
+	apiKey string
+	fetch  *credentialFetch
+}
+
+// credentialFetch is a call to Fetch in progress, shared by the callers
+// waiting for it.
+type credentialFetch struct {
+	done   chan struct{}
+	apiKey string
+	err    error
+}
+
+// fetchTimeout bounds a call to Fetch, which runs independently of the
+// callers waiting for it.
+const fetchTimeout = 30 * time.Second
+
+// APIKey returns the cached key, calling Fetch if there is none.
+func (c *CallbackCredentials) APIKey(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	apiKey := c.apiKey
+	c.mu.Unlock()
+	if apiKey != "" {
+		return apiKey, nil
+	}
+	return c._fetch(ctx)
+}
+
+// Refresh fetches a new key. A call made while another fetch is in progress
+// waits for that fetch instead.
+func (c *CallbackCredentials) Refresh(ctx context.Context) error {
+	_, err := c._fetch(ctx)
+	return err
+}
+
+// _fetch calls Fetch, or waits for the call already in progress. Fetch is
+// not bound to ctx, so that a caller giving up does not fail the others.
+func (c *CallbackCredentials) _fetch(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	fetch := c.fetch
+	if fetch == nil {
+		fetch = &credentialFetch{done: make(chan struct{})}
+		c.fetch = fetch
+		go c._runFetch(fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-fetch.done:
+		return fetch.apiKey, fetch.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (c *CallbackCredentials) _runFetch(fetch *credentialFetch) {
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	fetch.apiKey, fetch.err = c.Fetch(ctx)
+
+	c.mu.Lock()
+	if fetch.err == nil {
+		c.apiKey = fetch.apiKey
+	}
+	c.fetch = nil
+	c.mu.Unlock()
+	close(fetch.done)
+}
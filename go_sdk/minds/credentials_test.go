@@ -0,0 +1,134 @@
+package minds
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCredentialProviders(t *testing.T) {
+	t.Setenv("MINDS_TEST_KEY", "env-key")
+	path := filepath.Join(t.TempDir(), "key")
+	os.WriteFile(path, []byte(" file-key\n"), 0o600)
+
+	tests := []struct {
+		name     string
+		provider CredentialProvider
+		want     string
+		wantErr  bool
+	}{
+		{"env", &EnvCredentials{Name: "MINDS_TEST_KEY"}, "env-key", false},
+		{"env unset", &EnvCredentials{Name: "MINDS_TEST_UNSET"}, "", true},
+		{"file", &FileCredentials{Path: path}, "file-key", false},
+		{"missing file", &FileCredentials{Path: path + ".missing"}, "", true},
+		{"callback", &CallbackCredentials{Fetch: func(ctx context.Context) (string, error) { return "cb-key", nil }}, "cb-key", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.provider.APIKey(context.Background())
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("APIKey() = %q, %v; want %q, wantErr %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFileCredentialsReloadOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	os.WriteFile(path, []byte("old"), 0o600)
+	creds := &FileCredentials{Path: path}
+	if key, _ := creds.APIKey(context.Background()); key != "old" {
+		t.Fatalf("APIKey() = %q, want old", key)
+	}
+
+	os.WriteFile(path, []byte("new"), 0o600)
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(path, later, later)
+	if key, _ := creds.APIKey(context.Background()); key != "new" {
+		t.Errorf("APIKey() after rotation = %q, want new", key)
+	}
+}
+
+func TestCredentialsRetryAfterRotation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	fetches := 0
+	creds := &CallbackCredentials{Fetch: func(ctx context.Context) (string, error) {
+		fetches++
+		if fetches == 1 {
+			return "key-1", nil
+		}
+		return "key-2", nil
+	}}
+	client := NewClient("", srv.URL, WithCredentials(creds))
+	if _, err := client.Skills.List(); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if fetches != 2 {
+		t.Errorf("fetches = %d, want 2", fetches)
+	}
+}
+
+func TestCallbackCredentialsShareFetch(t *testing.T) {
+	release := make(chan struct{})
+	var fetches int32
+	creds := &CallbackCredentials{Fetch: func(ctx context.Context) (string, error) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		return "key", nil
+	}}
+
+	// The first caller gives up; the fetch it started still serves the others.
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := creds.APIKey(ctx)
+		first <- err
+	}()
+	for atomic.LoadInt32(&fetches) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled caller error = %v, want context.Canceled", err)
+	}
+
+	var wg sync.WaitGroup
+	keys := make([]string, 5)
+	for i := range keys {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				creds.Refresh(context.Background())
+			}
+			keys[i], _ = creds.APIKey(context.Background())
+		}(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if fetches != 1 {
+		t.Errorf("fetches = %d, want 1", fetches)
+	}
+	for i, key := range keys {
+		if key != "key" {
+			t.Errorf("caller %d got %q, want key", i, key)
+		}
+	}
+}
@@ -1,10 +1,13 @@
 package minds
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 )
 
 // DatabaseConfig represents the configuration for a database data source.
@@ -17,11 +20,22 @@ type DatabaseConfig struct {
 }
 
 // Datasource represents a data source connected to MindsDB.
-type Datasource DatabaseConfig
+// Tables and Columns only work on datasources returned by Datasources.
+type Datasource struct {
+	DatabaseConfig
+	api *RestAPI
+}
+
+// Column describes a column of a datasource table.
+type Column struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
 
 // Datasources manages interactions with MindsDB data sources.
 type Datasources struct {
-	api *RestAPI
+	api      *RestAPI
+	handlers *handlerCatalog
 }
 
 // NewDatasources creates a new Datasources instance.
@@ -32,26 +46,42 @@ func NewDatasources(client *RestAPI) *Datasources {
 }
 
 // Create creates a new data source.
+// The configuration is validated against the server's handler catalog first.
 func (d *Datasources) Create(dsConfig *DatabaseConfig, replace bool) (*Datasource, error) {
+	return d.CreateContext(context.Background(), dsConfig, replace)
+}
+
+// CreateContext is like Create with a context.
+func (d *Datasources) CreateContext(ctx context.Context, dsConfig *DatabaseConfig, replace bool) (_ *Datasource, err error) {
+	ctx, span := d.api._startSpan(ctx, "Datasources.Create", AttrDatasource, dsConfig.Name)
+	defer func() { _endSpan(span, err) }()
+
+	if d.handlers != nil {
+		if err := d.handlers.validate(ctx, dsConfig); err != nil {
+			return nil, fmt.Errorf("error validating datasource: %w", err)
+		}
+	}
+
 	if replace {
 		// Attempt to retrieve the datasource, if it exists, delete it.
-		_, err := d.Get(dsConfig.Name)
+		_, err := d.GetContext(ctx, dsConfig.Name)
 		if err == nil { // If no error, the datasource exists.
-			err = d.Drop(dsConfig.Name)
+			err = d.DropContext(ctx, dsConfig.Name)
 			if err != nil {
 				return nil, fmt.Errorf("error replacing datasource: %w", err)
 			}
-		} else if _, ok := err.(*ObjectNotFound); !ok {
+		} else if !errors.As(err, new(*ObjectNotFound)) {
 			// If the error isn't ObjectNotFound, re-throw it.
 			return nil, fmt.Errorf("error checking for existing datasource: %w", err)
 		}
 		// If the datasource didn't exist, ObjectNotFound is expected. Continue with creation.
 	}
 
-	resp, err := d.api.post("/datasources", dsConfig)
+	resp, err := d.api.post(ctx, "/datasources", dsConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error creating datasource: %w", err)
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		// Handle non-200 status codes (e.g., return an error)
@@ -59,22 +89,31 @@ func (d *Datasources) Create(dsConfig *DatabaseConfig, replace bool) (*Datasourc
 		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
 	}
 
-	return d.Get(dsConfig.Name)
+	return d.GetContext(ctx, dsConfig.Name)
 }
 
 // List returns a list of all data sources.
 func (d *Datasources) List() ([]*Datasource, error) {
-	resp, err := d.api.get("/datasources")
+	return d.ListContext(context.Background())
+}
+
+// ListContext is like List with a context.
+func (d *Datasources) ListContext(ctx context.Context) (dsList []*Datasource, err error) {
+	ctx, span := d.api._startSpan(ctx, "Datasources.List")
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := d.api.get(ctx, "/datasources")
 	if err != nil {
 		return nil, fmt.Errorf("error listing datasources: %w", err)
 	}
+	defer resp.Body.Close()
 
 	var data []map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
 		return nil, fmt.Errorf("error decoding datasources response: %w", err)
 	}
 
-	dsList := []*Datasource{}
+	dsList = []*Datasource{}
 	for _, item := range data {
 		// Skip non-SQL datasources for now (adjust as needed)
 		if _, ok := item["engine"].(string); !ok {
@@ -90,6 +129,7 @@ func (d *Datasources) List() ([]*Datasource, error) {
 		if err := json.Unmarshal(jsonData, &ds); err != nil {
 			return nil, fmt.Errorf("error unmarshaling datasource item: %w", err)
 		}
+		ds.api = d.api
 		dsList = append(dsList, &ds)
 	}
 
@@ -98,10 +138,19 @@ func (d *Datasources) List() ([]*Datasource, error) {
 
 // Get retrieves a data source by name.
 func (d *Datasources) Get(name string) (*Datasource, error) {
-	resp, err := d.api.get("/datasources/" + name)
+	return d.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get with a context.
+func (d *Datasources) GetContext(ctx context.Context, name string) (_ *Datasource, err error) {
+	ctx, span := d.api._startSpan(ctx, "Datasources.Get", AttrDatasource, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := d.api.get(ctx, "/datasources/"+url.PathEscape(name))
 	if err != nil {
 		return nil, fmt.Errorf("error getting datasource: %w", err)
 	}
+	defer resp.Body.Close()
 
 	var data map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
@@ -123,15 +172,25 @@ func (d *Datasources) Get(name string) (*Datasource, error) {
 		return nil, fmt.Errorf("error unmarshaling datasource: %w", err)
 	}
 
+	ds.api = d.api
 	return &ds, nil
 }
 
 // Drop deletes a data source by name.
 func (d *Datasources) Drop(name string) error {
-	resp, err := d.api.delete("/datasources/" + name)
+	return d.DropContext(context.Background(), name)
+}
+
+// DropContext is like Drop with a context.
+func (d *Datasources) DropContext(ctx context.Context, name string) (err error) {
+	ctx, span := d.api._startSpan(ctx, "Datasources.Drop", AttrDatasource, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := d.api.delete(ctx, "/datasources/"+url.PathEscape(name))
 	if err != nil {
 		return fmt.Errorf("error deleting datasource: %w", err)
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		// Handle non-200 status codes (e.g., return an error)
 		body, _ := io.ReadAll(resp.Body)
@@ -139,3 +198,98 @@ func (d *Datasources) Drop(name string) error {
 	}
 	return nil
 }
+
+// Test checks that MindsDB can connect to the data source described by
+// dsConfig without creating it. A failed check is returned as ConnectionFailed.
+func (d *Datasources) Test(dsConfig *DatabaseConfig) error {
+	return d.TestContext(context.Background(), dsConfig)
+}
+
+// TestContext is like Test with a context.
+func (d *Datasources) TestContext(ctx context.Context, dsConfig *DatabaseConfig) (err error) {
+	ctx, span := d.api._startSpan(ctx, "Datasources.Test", AttrDatasource, dsConfig.Name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := d.api.post(ctx, "/datasources/test", dsConfig)
+	if err != nil {
+		return fmt.Errorf("error testing datasource: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success      bool   `json:"success"`
+		ErrorMessage string `json:"error_message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("error decoding datasource test response: %w", err)
+	}
+	if !result.Success {
+		return &ConnectionFailed{Message: fmt.Sprintf("%s: %s", dsConfig.Name, result.ErrorMessage)}
+	}
+	return nil
+}
+
+// Tables returns the names of the tables available in the data source.
+func (ds *Datasource) Tables() ([]string, error) {
+	return ds.TablesContext(context.Background())
+}
+
+// TablesContext is like Tables with a context.
+func (ds *Datasource) TablesContext(ctx context.Context) (_ []string, err error) {
+	if ds.api == nil {
+		return nil, _errUnbound("datasource", ds.Name)
+	}
+	ctx, span := ds.api._startSpan(ctx, "Datasource.Tables", AttrDatasource, ds.Name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := ds.api.get(ctx, fmt.Sprintf("/datasources/%s/tables", url.PathEscape(ds.Name)))
+	if err != nil {
+		return nil, fmt.Errorf("error listing datasource tables: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var data []struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("error decoding datasource tables response: %w", err)
+	}
+
+	tables := make([]string, 0, len(data))
+	for _, item := range data {
+		tables = append(tables, item.Name)
+	}
+	return tables, nil
+}
+
+// Columns returns the columns and their types for a table of the data source.
+func (ds *Datasource) Columns(table string) ([]*Column, error) {
+	return ds.ColumnsContext(context.Background(), table)
+}
+
+// ColumnsContext is like Columns with a context.
+func (ds *Datasource) ColumnsContext(ctx context.Context, table string) (_ []*Column, err error) {
+	if ds.api == nil {
+		return nil, _errUnbound("datasource", ds.Name)
+	}
+	ctx, span := ds.api._startSpan(ctx, "Datasource.Columns", AttrDatasource, ds.Name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := ds.api.get(ctx, fmt.Sprintf("/datasources/%s/tables/%s/columns", url.PathEscape(ds.Name), url.PathEscape(table)))
+	if err != nil {
+		return nil, fmt.Errorf("error listing table columns: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var columns []*Column
+	if err := json.NewDecoder(resp.Body).Decode(&columns); err != nil {
+		return nil, fmt.Errorf("error decoding table columns response: %w", err)
+	}
+	return columns, nil
+}
+
+// _errUnbound is returned by methods of objects that were not obtained from
+// a client and therefore cannot reach the server.
+func _errUnbound(kind string, name string) error {
+	return fmt.Errorf("%s %q is not bound to a client; get it from the client first", kind, name)
+}
@@ -0,0 +1,128 @@
+package minds
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDatasourceUnboundReturnsError(t *testing.T) {
+	ds := &Datasource{DatabaseConfig: DatabaseConfig{Name: "db"}}
+	if _, err := ds.Tables(); err == nil {
+		t.Error("Tables on an unbound datasource returned no error")
+	}
+	if _, err := ds.Columns("t"); err == nil {
+		t.Error("Columns on an unbound datasource returned no error")
+	}
+}
+
+func TestDatasourceColumnsEscapesPath(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		switch r.URL.Path {
+		case "/api/datasources/my db":
+			w.Write([]byte(`{"name": "my db", "engine": "postgres"}`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", srv.URL)
+	ds, err := client.Datasources.Get("my db")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if _, err := ds.Columns("a/b"); err != nil {
+		t.Fatalf("Columns: %v", err)
+	}
+	if want := "/api/datasources/my%20db/tables/a%2Fb/columns"; gotPath != want {
+		t.Errorf("path = %s, want %s", gotPath, want)
+	}
+}
+
+func TestDatasourcesTest(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		wantErr  bool
+	}{
+		{name: "success", response: `{"success": true}`},
+		{name: "connection failed", response: `{"success": false, "error_message": "password authentication failed"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got DatabaseConfig
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/datasources/test" {
+					t.Errorf("request = %s %s, want POST /api/datasources/test", r.Method, r.URL.Path)
+				}
+				json.NewDecoder(r.Body).Decode(&got)
+				w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+
+			client := NewClient("key", srv.URL)
+			err := client.Datasources.Test(&DatabaseConfig{Name: "db", Engine: "postgres"})
+			if got.Name != "db" || got.Engine != "postgres" {
+				t.Errorf("request body = %+v", got)
+			}
+			var failed *ConnectionFailed
+			if tt.wantErr {
+				if !errors.As(err, &failed) {
+					t.Fatalf("err = %v, want ConnectionFailed", err)
+				}
+				if !strings.Contains(failed.Message, "password authentication failed") {
+					t.Errorf("message = %q, want the server's error message", failed.Message)
+				}
+			} else if err != nil {
+				t.Fatalf("Test: %v", err)
+			}
+		})
+	}
+}
+
+func TestDatasourceTablesAndColumns(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/datasources/db":
+			w.Write([]byte(`{"name": "db", "engine": "postgres"}`))
+		case "/api/datasources/db/tables":
+			w.Write([]byte(`[{"name": "orders"}, {"name": "customers"}]`))
+		case "/api/datasources/db/tables/orders/columns":
+			w.Write([]byte(`[{"name": "id", "type": "integer"}, {"name": "total", "type": "numeric"}]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", srv.URL)
+	ds, err := client.Datasources.Get("db")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	tables, err := ds.Tables()
+	if err != nil {
+		t.Fatalf("Tables: %v", err)
+	}
+	if want := []string{"orders", "customers"}; !reflect.DeepEqual(tables, want) {
+		t.Errorf("tables = %v, want %v", tables, want)
+	}
+
+	columns, err := ds.Columns("orders")
+	if err != nil {
+		t.Fatalf("Columns: %v", err)
+	}
+	want := []*Column{{Name: "id", Type: "integer"}, {Name: "total", Type: "numeric"}}
+	if !reflect.DeepEqual(columns, want) {
+		t.Errorf("columns = %+v, want %+v", columns, want)
+	}
+}
@@ -1,6 +1,9 @@
 package minds
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // ObjectNotFound is raised when a requested object is not found.
 type ObjectNotFound struct {
@@ -46,3 +49,72 @@ type UnknownError struct {
 func (e *UnknownError) Error() string {
 	return fmt.Sprintf("Unknown error: %s", e.Message)
 }
+
+// ConnectionFailed is raised when MindsDB cannot connect to a data source.
+type ConnectionFailed struct {
+	Message string
+}
+
+func (e *ConnectionFailed) Error() string {
+	return fmt.Sprintf("Connection failed: %s", e.Message)
+}
+
+// InvalidConfig is raised when a configuration is rejected before reaching the server.
+type InvalidConfig struct {
+	Message string
+}
+
+func (e *InvalidConfig) Error() string {
+	return fmt.Sprintf("Invalid config: %s", e.Message)
+}
+
+// SQLError is raised when MindsDB fails to execute a SQL query.
+type SQLError struct {
+	Code    int
+	Message string
+}
+
+func (e *SQLError) Error() string {
+	return fmt.Sprintf("SQL error %d: %s", e.Code, e.Message)
+}
+
+// TrainingFailed is raised when a model fails to train.
+type TrainingFailed struct {
+	Message string
+}
+
+func (e *TrainingFailed) Error() string {
+	return fmt.Sprintf("Training failed: %s", e.Message)
+}
+
+// BudgetExceeded is raised when a completion is rejected because the usage
+// budget has been spent.
+type BudgetExceeded struct {
+	Message string
+}
+
+func (e *BudgetExceeded) Error() string {
+	return fmt.Sprintf("Budget exceeded: %s", e.Message)
+}
+
+// RateLimited is raised when the service rejects a request with 429 Too
+// Many Requests. RetryAfter is the period the service asked to wait, when
+// given.
+type RateLimited struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimited) Error() string {
+	return fmt.Sprintf("Rate limited: %s", e.Message)
+}
+
+// CircuitOpenError is raised when a request is not sent because the circuit
+// breaker for its endpoint is open.
+type CircuitOpenError struct {
+	Message string
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("Circuit open: %s", e.Message)
+}
@@ -0,0 +1,240 @@
+package minds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Endpoint is a MindsDB deployment the client can send requests to.
+type Endpoint struct {
+	// BaseURL is the deployment's REST API URL, as passed to NewRestAPI.
+	BaseURL string
+	// LLMURL is the deployment's OpenAI-compatible endpoint. When empty it
+	// is derived from BaseURL.
+	LLMURL string
+}
+
+// EndpointStats describes the health of an endpoint.
+type EndpointStats struct {
+	BaseURL             string
+	Healthy             bool
+	Active              bool
+	Requests            int
+	Failures            int
+	ConsecutiveFailures int
+	LastError           string
+	LastFailure         time.Time
+	AverageLatency      time.Duration
+}
+
+// EndpointPool selects the endpoint used for requests. The active endpoint
+// is kept as long as it is healthy; when a request to it fails, the request
+// is retried on the next healthy endpoint, which then becomes active.
+// Transport errors, open circuits and 5xx responses count as failures.
+type EndpointPool struct {
+	// FailureThreshold is the number of consecutive failures that marks an
+	// endpoint unhealthy. It defaults to 1.
+	FailureThreshold int
+	// Cooldown is how long an unhealthy endpoint is skipped. It defaults to
+	// 30 seconds.
+	Cooldown time.Duration
+
+	mu        sync.Mutex
+	endpoints []*endpointState
+	active    int
+}
+
+type endpointState struct {
+	baseURL string
+	llmURL  string
+	stats   EndpointStats
+	latency time.Duration
+}
+
+// NewEndpointPool creates an EndpointPool preferring the endpoints in order.
+func NewEndpointPool(endpoints ...Endpoint) (*EndpointPool, error) {
+	if len(endpoints) == 0 {
+		return nil, &InvalidConfig{Message: "no endpoints"}
+	}
+	pool := &EndpointPool{}
+	for _, endpoint := range endpoints {
+		baseURL := _normalizeBaseURL(endpoint.BaseURL)
+		llmURL := strings.TrimSuffix(endpoint.LLMURL, "/")
+		if llmURL == "" {
+			derived, err := _deriveLLMURL(baseURL)
+			if err != nil {
+				return nil, err
+			}
+			llmURL = derived
+		}
+		pool.endpoints = append(pool.endpoints, &endpointState{
+			baseURL: baseURL,
+			llmURL:  llmURL,
+			stats:   EndpointStats{BaseURL: baseURL},
+		})
+	}
+	return pool, nil
+}
+
+// WithEndpoints sends requests to pool's endpoints instead of the base URL
+// passed to NewRestAPI.
+func WithEndpoints(pool *EndpointPool) Option {
+	return func(r *RestAPI) {
+		r.Endpoints = pool
+		r.BaseURL = pool.endpoints[0].baseURL
+	}
+}
+
+// Stats returns the health of each endpoint, in preference order.
+func (p *EndpointPool) Stats() []EndpointStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]EndpointStats, 0, len(p.endpoints))
+	for i, endpoint := range p.endpoints {
+		s := endpoint.stats
+		s.Healthy = p._healthy(endpoint)
+		s.Active = i == p.active
+		if s.Requests > 0 {
+			s.AverageLatency = endpoint.latency / time.Duration(s.Requests)
+		}
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// _pick returns the endpoint to try next, skipping those already tried.
+// Unhealthy endpoints are only picked when no healthy one is left.
+func (p *EndpointPool) _pick(tried map[int]bool) (int, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fallback := -1
+	for offset := 0; offset < len(p.endpoints); offset++ {
+		i := (p.active + offset) % len(p.endpoints)
+		if tried[i] {
+			continue
+		}
+		if p._healthy(p.endpoints[i]) {
+			return i, true
+		}
+		if fallback < 0 {
+			fallback = i
+		}
+	}
+	return fallback, fallback >= 0
+}
+
+// _record updates the stats of endpoint i and makes it active on success.
+func (p *EndpointPool) _record(i int, latency time.Duration, err error) (switched bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	endpoint := p.endpoints[i]
+	endpoint.stats.Requests++
+	endpoint.latency += latency
+	if err != nil {
+		endpoint.stats.Failures++
+		endpoint.stats.ConsecutiveFailures++
+		endpoint.stats.LastError = err.Error()
+		endpoint.stats.LastFailure = time.Now()
+		return false
+	}
+	endpoint.stats.ConsecutiveFailures = 0
+	switched = p.active != i
+	p.active = i
+	return switched
+}
+
+func (p *EndpointPool) _healthy(endpoint *endpointState) bool {
+	threshold := p.FailureThreshold
+	if threshold <= 0 {
+		threshold = 1
+	}
+	cooldown := p.Cooldown
+	if cooldown <= 0 {
+		cooldown = 30 * time.Second
+	}
+	return endpoint.stats.ConsecutiveFailures < threshold || time.Since(endpoint.stats.LastFailure) >= cooldown
+}
+
+// _rewrite maps a URL built for the first endpoint onto endpoint i.
+func (p *EndpointPool) _rewrite(u *url.URL, i int) (*url.URL, error) {
+	primary, target := p.endpoints[0], p.endpoints[i]
+	raw := u.String()
+	switch {
+	case strings.HasPrefix(raw, primary.baseURL):
+		raw = target.baseURL + strings.TrimPrefix(raw, primary.baseURL)
+	case strings.HasPrefix(raw, primary.llmURL):
+		raw = target.llmURL + strings.TrimPrefix(raw, primary.llmURL)
+	default:
+		return u, nil
+	}
+	return url.Parse(raw)
+}
+
+// failoverTransport sends each request to the pool's active endpoint and
+// retries failed requests on the other endpoints.
+type failoverTransport struct {
+	api  *RestAPI
+	pool *EndpointPool
+	base http.RoundTripper
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	replayable := _canReplay(req)
+	tried := make(map[int]bool)
+	for {
+		i, ok := t.pool._pick(tried)
+		if !ok {
+			return nil, fmt.Errorf("error sending request: no endpoint available")
+		}
+		tried[i] = true
+
+		attempt := req.Clone(req.Context())
+		if len(tried) > 1 {
+			var err error
+			if attempt, err = _replay(req); err != nil {
+				return nil, err
+			}
+		}
+		target, err := t.pool._rewrite(req.URL, i)
+		if err != nil {
+			return nil, fmt.Errorf("error rewriting request URL: %w", err)
+		}
+		attempt.URL = target
+		attempt.Host = ""
+
+		start := time.Now()
+		resp, err := t.base.RoundTrip(attempt)
+		failure := err
+		if _isRateLimited(err) {
+			// The account is limited on every endpoint alike.
+			failure = nil
+		} else if err == nil && resp.StatusCode >= http.StatusInternalServerError {
+			failure = fmt.Errorf("%s", resp.Status)
+		}
+		if failure != nil && errors.Is(req.Context().Err(), context.Canceled) {
+			return resp, err
+		}
+		if t.pool._record(i, time.Since(start), failure) {
+			t.api._log(LevelWarn, "failed over to endpoint", "endpoint", t.pool.endpoints[i].baseURL)
+		}
+
+		last := len(tried) == len(t.pool.endpoints)
+		if failure == nil || !replayable || last {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		_countRetry(req.Context())
+		t.api._log(LevelWarn, "endpoint failed, trying next", "endpoint", t.pool.endpoints[i].baseURL, "error", failure)
+	}
+}
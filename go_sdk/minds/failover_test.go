@@ -0,0 +1,114 @@
+package minds
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestEndpointPoolPick(t *testing.T) {
+	tests := []struct {
+		name     string
+		active   int
+		failures []int
+		tried    map[int]bool
+		want     int
+		wantOK   bool
+	}{
+		{"active endpoint", 0, []int{0, 0, 0}, nil, 0, true},
+		{"keeps the active endpoint", 2, []int{0, 0, 0}, nil, 2, true},
+		{"skips unhealthy", 0, []int{1, 0, 0}, nil, 1, true},
+		{"skips tried", 0, []int{0, 0, 0}, map[int]bool{0: true}, 1, true},
+		{"wraps around", 2, []int{0, 0, 0}, map[int]bool{2: true}, 0, true},
+		{"falls back to unhealthy", 0, []int{1, 1, 0}, map[int]bool{2: true}, 0, true},
+		{"nothing left", 0, []int{0, 0, 0}, map[int]bool{0: true, 1: true, 2: true}, -1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, _ := NewEndpointPool(
+				Endpoint{BaseURL: "http://a"},
+				Endpoint{BaseURL: "http://b"},
+				Endpoint{BaseURL: "http://c"},
+			)
+			pool.active = tt.active
+			for i, failures := range tt.failures {
+				pool.endpoints[i].stats.ConsecutiveFailures = failures
+				pool.endpoints[i].stats.LastFailure = time.Now()
+			}
+			got, ok := pool._pick(tt.tried)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("_pick() = %d, %v; want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEndpointPoolCooldown(t *testing.T) {
+	pool, _ := NewEndpointPool(Endpoint{BaseURL: "http://a"}, Endpoint{BaseURL: "http://b"})
+	pool.Cooldown = time.Millisecond
+	pool._record(0, 0, errors.New("down"))
+	if i, _ := pool._pick(nil); i != 1 {
+		t.Fatalf("_pick() after failure = %d, want 1", i)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if i, _ := pool._pick(nil); i != 0 {
+		t.Errorf("_pick() after cooldown = %d, want 0", i)
+	}
+}
+
+func TestEndpointPoolRewrite(t *testing.T) {
+	pool, _ := NewEndpointPool(
+		Endpoint{BaseURL: "http://a", LLMURL: "http://llm-a"},
+		Endpoint{BaseURL: "http://b/"},
+	)
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"http://a/api/minds?limit=1", "http://b/api/minds?limit=1"},
+		{"http://llm-a/chat/completions", "http://ai.b/chat/completions"},
+		{"http://elsewhere/x", "http://elsewhere/x"},
+	}
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.raw)
+		got, err := pool._rewrite(u, 1)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("_rewrite(%q) = %v, %v; want %q", tt.raw, got, err, tt.want)
+		}
+	}
+}
+
+func TestFailoverTransport(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	var bodies []string
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Write([]byte(`{"name": "s", "type": "text2sql", "params": {}}`))
+	}))
+	defer up.Close()
+
+	pool, _ := NewEndpointPool(Endpoint{BaseURL: down.URL}, Endpoint{BaseURL: up.URL})
+	client := NewClient("key", "", WithEndpoints(pool))
+	if _, err := client.Skills.Create(&SkillConfig{Name: "s", Type: "text2sql"}, false); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if len(bodies) == 0 || bodies[0] == "" {
+		t.Errorf("the request body was not replayed on the second endpoint: %q", bodies)
+	}
+
+	stats := pool.Stats()
+	if stats[0].Healthy || stats[0].Failures != 1 {
+		t.Errorf("stats[0] = %+v, want one failure and unhealthy", stats[0])
+	}
+	if !stats[1].Active {
+		t.Errorf("stats[1] = %+v, want active", stats[1])
+	}
+}
@@ -0,0 +1,200 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FilesDatasource is the name of the MindsDB integration holding uploaded files.
+const FilesDatasource = "files"
+
+// supportedFileTypes lists the file extensions MindsDB can read as tables.
+var supportedFileTypes = map[string]bool{
+	".csv":     true,
+	".json":    true,
+	".parquet": true,
+	".xlsx":    true,
+}
+
+// File represents a file uploaded to MindsDB. Each file is exposed as a table
+// of the files datasource.
+type File struct {
+	api      *RestAPI
+	Name     string   `json:"name"`
+	RowCount int      `json:"row_count"`
+	Columns  []string `json:"columns"`
+}
+
+// Datasource returns a datasource reference for the file that can be passed
+// to CreateMindOptions.Datasources.
+func (f *File) Datasource() *Datasource {
+	return &Datasource{
+		DatabaseConfig: DatabaseConfig{
+			Name:   FilesDatasource,
+			Engine: FilesDatasource,
+			Tables: []string{f.Name},
+		},
+		api: f.api,
+	}
+}
+
+// Files manages files uploaded to MindsDB.
+type Files struct {
+	api *RestAPI
+}
+
+// NewFiles creates a new Files instance.
+func NewFiles(client *RestAPI) *Files {
+	return &Files{
+		api: client,
+	}
+}
+
+// Upload uploads a local file under the given name. The file type is taken
+// from the extension of path.
+func (f *Files) Upload(name string, path string) (*File, error) {
+	return f.UploadContext(context.Background(), name, path)
+}
+
+// UploadContext is like Upload with a context.
+func (f *Files) UploadContext(ctx context.Context, name string, path string) (_ *File, err error) {
+	ctx, span := f.api._startSpan(ctx, "Files.Upload", AttrFile, name)
+	defer func() { _endSpan(span, err) }()
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	return f.UploadReaderContext(ctx, name, filepath.Base(path), file)
+}
+
+// UploadReader uploads the contents of r under the given name. fileName is
+// only used to tell the server the file type.
+func (f *Files) UploadReader(name string, fileName string, r io.Reader) (*File, error) {
+	return f.UploadReaderContext(context.Background(), name, fileName, r)
+}
+
+// UploadReaderContext is like UploadReader with a context.
+func (f *Files) UploadReaderContext(ctx context.Context, name string, fileName string, r io.Reader) (_ *File, err error) {
+	ctx, span := f.api._startSpan(ctx, "Files.UploadReader", AttrFile, name)
+	defer func() { _endSpan(span, err) }()
+
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if !supportedFileTypes[ext] {
+		return nil, &ObjectNotSupported{Message: fmt.Sprintf("unsupported file type: %s", fileName)}
+	}
+
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		writer.CloseWithError(_writeFileForm(form, fileName, r))
+	}()
+
+	resp, err := f.api.upload(ctx, "/files/"+url.PathEscape(name), form.FormDataContentType(), body)
+	// Unblock the writer if the request ended before the body was consumed.
+	body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error uploading file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return f.GetContext(ctx, name)
+}
+
+func _writeFileForm(form *multipart.Writer, fileName string, r io.Reader) error {
+	if err := form.WriteField("original_file_name", fileName); err != nil {
+		return err
+	}
+	part, err := form.CreateFormFile("file", fileName)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return err
+	}
+	return form.Close()
+}
+
+// List returns all uploaded files.
+func (f *Files) List() ([]*File, error) {
+	return f.ListContext(context.Background())
+}
+
+// ListContext is like List with a context.
+func (f *Files) ListContext(ctx context.Context) (_ []*File, err error) {
+	ctx, span := f.api._startSpan(ctx, "Files.List")
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := f.api.get(ctx, "/files")
+	if err != nil {
+		return nil, fmt.Errorf("error listing files: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var files []*File
+	if err := json.NewDecoder(resp.Body).Decode(&files); err != nil {
+		return nil, fmt.Errorf("error decoding files response: %w", err)
+	}
+	for _, file := range files {
+		file.api = f.api
+	}
+	return files, nil
+}
+
+// Get retrieves an uploaded file by name.
+func (f *Files) Get(name string) (*File, error) {
+	return f.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get with a context.
+func (f *Files) GetContext(ctx context.Context, name string) (_ *File, err error) {
+	ctx, span := f.api._startSpan(ctx, "Files.Get", AttrFile, name)
+	defer func() { _endSpan(span, err) }()
+
+	files, err := f.ListContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if file.Name == name {
+			return file, nil
+		}
+	}
+	return nil, &ObjectNotFound{Message: fmt.Sprintf("file %s", name)}
+}
+
+// Drop deletes an uploaded file by name.
+func (f *Files) Drop(name string) error {
+	return f.DropContext(context.Background(), name)
+}
+
+// DropContext is like Drop with a context.
+func (f *Files) DropContext(ctx context.Context, name string) (err error) {
+	ctx, span := f.api._startSpan(ctx, "Files.Drop", AttrFile, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := f.api.delete(ctx, "/files/"+url.PathEscape(name))
+	if err != nil {
+		return fmt.Errorf("error deleting file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
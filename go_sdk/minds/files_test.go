@@ -0,0 +1,218 @@
+package minds
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMindCreateMergesFilesIntoOneDatasource(t *testing.T) {
+	var payload struct {
+		Datasources []interface{} `json:"datasources"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			json.NewDecoder(r.Body).Decode(&payload)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.Write([]byte(`{"name": "m"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", srv.URL)
+	sales := &File{api: client.api, Name: "sales"}
+	returns := &File{api: client.api, Name: "returns"}
+	opts := &CreateMindOptions{Datasources: []interface{}{sales, "warehouse", returns, sales}}
+	if _, err := client.Minds.Create("m", opts, false); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	want := []interface{}{
+		map[string]interface{}{"name": FilesDatasource, "tables": []interface{}{"sales", "returns"}},
+		"warehouse",
+	}
+	if !reflect.DeepEqual(payload.Datasources, want) {
+		t.Errorf("datasources = %v, want %v", payload.Datasources, want)
+	}
+}
+
+func TestDatasourcePayloadUsesWholeDatasourceWhenReferencedByName(t *testing.T) {
+	got := _datasourcePayload([]*mindDatasource{
+		{Name: FilesDatasource, Tables: []string{"sales"}},
+		{Name: FilesDatasource},
+	})
+	if want := []interface{}{FilesDatasource}; !reflect.DeepEqual(got, want) {
+		t.Errorf("payload = %v, want %v", got, want)
+	}
+}
+
+// mindServer serves a mind with both datasource forms and records the
+// mutating requests it receives.
+type mindServer struct {
+	requests []string
+	patch    map[string]interface{}
+}
+
+func (s *mindServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Write([]byte(`{"name": "m", "project": "mindsdb", "datasources": [{"name": "files", "tables": ["sales", "returns"]}, "warehouse"]}`))
+	case http.MethodPatch:
+		json.NewDecoder(r.Body).Decode(&s.patch)
+		fallthrough
+	default:
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	}
+}
+
+func TestMindDatasourcesDecodeBothForms(t *testing.T) {
+	srv := httptest.NewServer(&mindServer{})
+	defer srv.Close()
+
+	mind, err := NewClient("key", srv.URL).Minds.Get("m")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if want := []string{FilesDatasource, "warehouse"}; !reflect.DeepEqual(mind.Datasources, want) {
+		t.Errorf("Datasources = %v, want %v", mind.Datasources, want)
+	}
+}
+
+func TestMindDelDatasourceRemovesOnlyTheFileTable(t *testing.T) {
+	s := &mindServer{}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	mind, err := NewClient("key", srv.URL).Minds.Get("m")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if err := mind.DelDatasource(&File{Name: "sales"}); err != nil {
+		t.Fatalf("DelDatasource(*File) error = %v", err)
+	}
+	if err := mind.DelDatasource(&View{Project: "proj", Name: "v"}); err != nil {
+		t.Fatalf("DelDatasource(*View) error = %v", err)
+	}
+
+	wantRequests := []string{
+		"PATCH /api/projects/mindsdb/minds/m",
+		"DELETE /api/projects/mindsdb/minds/m/datasources/proj.v",
+	}
+	if !reflect.DeepEqual(s.requests, wantRequests) {
+		t.Errorf("requests = %v, want %v", s.requests, wantRequests)
+	}
+	wantPatch := map[string]interface{}{
+		"datasources": []interface{}{
+			map[string]interface{}{"name": FilesDatasource, "tables": []interface{}{"returns"}},
+			"warehouse",
+		},
+	}
+	if !reflect.DeepEqual(s.patch, wantPatch) {
+		t.Errorf("patch = %v, want %v", s.patch, wantPatch)
+	}
+}
+
+func TestMindDelDatasourceUnknownFile(t *testing.T) {
+	s := &mindServer{}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	mind, err := NewClient("key", srv.URL).Minds.Get("m")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	err = mind.DelDatasource(&File{Name: "inventory"})
+	if !errors.As(err, new(*ObjectNotFound)) {
+		t.Errorf("err = %v, want ObjectNotFound", err)
+	}
+	if len(s.requests) != 0 {
+		t.Errorf("requests = %v, want none", s.requests)
+	}
+}
+
+func TestFilesUpload(t *testing.T) {
+	var gotPath, gotFileName, gotContent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`[{"name": "my sales", "row_count": 2, "columns": ["id", "total"]}]`))
+			return
+		}
+		gotPath = r.URL.EscapedPath()
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Errorf("Content-Type: %v", err)
+			return
+		}
+		form, err := multipart.NewReader(r.Body, params["boundary"]).ReadForm(1 << 20)
+		if err != nil {
+			t.Errorf("ReadForm: %v", err)
+			return
+		}
+		gotFileName = form.Value["original_file_name"][0]
+		part, err := form.File["file"][0].Open()
+		if err != nil {
+			t.Errorf("open form file: %v", err)
+			return
+		}
+		content, _ := io.ReadAll(part)
+		gotContent = string(content)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "sales.csv")
+	if err := os.WriteFile(path, []byte("id,total\n1,10\n2,20\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := NewClient("key", srv.URL).Files.Upload("my sales", path)
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if gotPath != "/api/files/my%20sales" {
+		t.Errorf("path = %s, want /api/files/my%%20sales", gotPath)
+	}
+	if gotFileName != "sales.csv" {
+		t.Errorf("original_file_name = %q, want sales.csv", gotFileName)
+	}
+	if gotContent != "id,total\n1,10\n2,20\n" {
+		t.Errorf("file content = %q", gotContent)
+	}
+	if file.Name != "my sales" || file.RowCount != 2 {
+		t.Errorf("file = %+v", file)
+	}
+}
+
+type failingReader struct{ err error }
+
+func (r failingReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestFilesUploadReaderError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+	}))
+	defer srv.Close()
+
+	readErr := errors.New("disk on fire")
+	_, err := NewClient("key", srv.URL).Files.UploadReader("sales", "sales.csv", failingReader{readErr})
+	if err == nil || !strings.Contains(err.Error(), readErr.Error()) {
+		t.Errorf("err = %v, want it to carry the reader's error", err)
+	}
+}
+
+func TestFilesUploadUnsupportedType(t *testing.T) {
+	client := NewClient("key", "http://127.0.0.1:1")
+	_, err := client.Files.UploadReader("notes", "notes.txt", strings.NewReader("hi"))
+	if !errors.As(err, new(*ObjectNotSupported)) {
+		t.Errorf("err = %v, want ObjectNotSupported", err)
+	}
+}
@@ -0,0 +1,188 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConnectionArg describes a connection argument accepted by a handler.
+type ConnectionArg struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+	Secret      bool   `json:"secret"`
+}
+
+// Handler describes an integration (engine) available on the server.
+type Handler struct {
+	Name           string           `json:"name"`
+	Title          string           `json:"title"`
+	Description    string           `json:"description"`
+	Type           string           `json:"type"`
+	ConnectionArgs []*ConnectionArg `json:"-"`
+}
+
+// UnmarshalJSON decodes a handler, flattening the connection_args map into
+// ConnectionArgs sorted by argument name.
+func (h *Handler) UnmarshalJSON(data []byte) error {
+	type handler Handler
+	var raw struct {
+		handler
+		ConnectionArgs map[string]*ConnectionArg `json:"connection_args"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*h = Handler(raw.handler)
+	h.ConnectionArgs = make([]*ConnectionArg, 0, len(raw.ConnectionArgs))
+	for name, arg := range raw.ConnectionArgs {
+		if arg == nil {
+			arg = &ConnectionArg{}
+		}
+		arg.Name = name
+		h.ConnectionArgs = append(h.ConnectionArgs, arg)
+	}
+	sort.Slice(h.ConnectionArgs, func(i, j int) bool {
+		return h.ConnectionArgs[i].Name < h.ConnectionArgs[j].Name
+	})
+	return nil
+}
+
+// Validate checks a datasource configuration against the handler's
+// connection argument schema. Arguments the schema does not list are
+// accepted, because handler schemas are not always complete.
+func (h *Handler) Validate(dsConfig *DatabaseConfig) error {
+	var problems []string
+	for _, arg := range h.ConnectionArgs {
+		value, ok := dsConfig.ConnectionData[arg.Name]
+		if !ok {
+			if arg.Required {
+				problems = append(problems, fmt.Sprintf("missing required argument %q", arg.Name))
+			}
+			continue
+		}
+		if err := _checkArgType(arg.Type, value); err != nil {
+			problems = append(problems, fmt.Sprintf("argument %q: %s", arg.Name, err))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return &InvalidConfig{Message: fmt.Sprintf("%s (%s): %s", dsConfig.Name, h.Name, strings.Join(problems, "; "))}
+	}
+	return nil
+}
+
+// _unknownArgs returns the sorted names of dsConfig's arguments that the
+// handler's schema does not list.
+func (h *Handler) _unknownArgs(dsConfig *DatabaseConfig) []string {
+	// Handlers that do not publish a schema accept anything.
+	if len(h.ConnectionArgs) == 0 {
+		return nil
+	}
+	known := make(map[string]bool, len(h.ConnectionArgs))
+	for _, arg := range h.ConnectionArgs {
+		known[arg.Name] = true
+	}
+	var unknown []string
+	for name := range dsConfig.ConnectionData {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+func _checkArgType(argType string, value string) error {
+	switch argType {
+	case "int":
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("expected an integer, got %q", value)
+		}
+	case "bool":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("expected a boolean, got %q", value)
+		}
+	}
+	return nil
+}
+
+// Handlers returns the integrations available on the server together with
+// their connection argument schemas.
+func (c *Client) Handlers() ([]*Handler, error) {
+	return c.HandlersContext(context.Background())
+}
+
+// HandlersContext is like Handlers with a context.
+func (c *Client) HandlersContext(ctx context.Context) (_ []*Handler, err error) {
+	ctx, span := c.api._startSpan(ctx, "Client.Handlers")
+	defer func() { _endSpan(span, err) }()
+
+	handlers, err := _listHandlers(ctx, c.api)
+	if err != nil {
+		return nil, err
+	}
+	c.handlers.set(_indexHandlers(handlers))
+	return handlers, nil
+}
+
+func _listHandlers(ctx context.Context, api *RestAPI) ([]*Handler, error) {
+	resp, err := api.get(ctx, "/handlers")
+	if err != nil {
+		return nil, fmt.Errorf("error listing handlers: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var handlers []*Handler
+	if err := json.NewDecoder(resp.Body).Decode(&handlers); err != nil {
+		return nil, fmt.Errorf("error decoding handlers response: %w", err)
+	}
+	return handlers, nil
+}
+
+// handlerCatalog caches the server's handlers, by name, for client-side
+// validation.
+type handlerCatalog struct {
+	catalog[map[string]*Handler]
+}
+
+func newHandlerCatalog(api *RestAPI) *handlerCatalog {
+	return &handlerCatalog{catalog[map[string]*Handler]{
+		api:  api,
+		name: "handlers",
+		fetch: func(ctx context.Context, api *RestAPI) (map[string]*Handler, error) {
+			handlers, err := _listHandlers(ctx, api)
+			return _indexHandlers(handlers), err
+		},
+	}}
+}
+
+func _indexHandlers(handlers []*Handler) map[string]*Handler {
+	index := make(map[string]*Handler, len(handlers))
+	for _, h := range handlers {
+		index[h.Name] = h
+	}
+	return index
+}
+
+// validate checks dsConfig against the catalog. Arguments missing from the
+// handler's schema are logged rather than rejected.
+func (hc *handlerCatalog) validate(ctx context.Context, dsConfig *DatabaseConfig) error {
+	handlers, ok := hc.get(ctx)
+	if !ok {
+		return nil
+	}
+	handler, ok := handlers[dsConfig.Engine]
+	if !ok {
+		return &InvalidConfig{Message: fmt.Sprintf("%s: unknown engine %q", dsConfig.Name, dsConfig.Engine)}
+	}
+	if unknown := handler._unknownArgs(dsConfig); len(unknown) > 0 {
+		hc.api._log(LevelWarn, "connection arguments not in handler schema", "datasource", dsConfig.Name, "engine", dsConfig.Engine, "arguments", strings.Join(unknown, ","))
+	}
+	return handler.Validate(dsConfig)
+}
@@ -0,0 +1,98 @@
+package minds
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const postgresHandlerJSON = `{
+	"name": "postgres",
+	"connection_args": {
+		"host": {"type": "str", "required": true},
+		"port": {"type": "int"},
+		"ssl": {"type": "bool"}
+	}
+}`
+
+func TestHandlerValidate(t *testing.T) {
+	var handler Handler
+	if err := json.Unmarshal([]byte(postgresHandlerJSON), &handler); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    map[string]string
+		wantErr bool
+	}{
+		{"valid", map[string]string{"host": "db", "port": "5432", "ssl": "true"}, false},
+		{"missing required", map[string]string{"port": "5432"}, true},
+		{"bad int", map[string]string{"host": "db", "port": "x"}, true},
+		{"bad bool", map[string]string{"host": "db", "ssl": "maybe"}, true},
+		{"unknown argument accepted", map[string]string{"host": "db", "sslmode": "require"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.Validate(&DatabaseConfig{Name: "ds", Engine: "postgres", ConnectionData: tt.data})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.As(err, new(*InvalidConfig)) {
+				t.Errorf("Validate() error = %T, want *InvalidConfig", err)
+			}
+		})
+	}
+
+	unknown := handler._unknownArgs(&DatabaseConfig{ConnectionData: map[string]string{"host": "db", "sslmode": "require"}})
+	if len(unknown) != 1 || unknown[0] != "sslmode" {
+		t.Errorf("_unknownArgs() = %v, want [sslmode]", unknown)
+	}
+}
+
+func TestDatasourceCreateSkipsValidationWhenCatalogFails(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusForbidden, http.StatusInternalServerError} {
+		handlerCalls := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/handlers":
+				handlerCalls++
+				w.WriteHeader(status)
+			case "/api/datasources":
+				w.WriteHeader(http.StatusOK)
+			default:
+				w.Write([]byte(`{"name": "ds", "engine": "postgres"}`))
+			}
+		}))
+
+		client := NewClient("key", srv.URL)
+		cfg := &DatabaseConfig{Name: "ds", Engine: "postgres"}
+		for i := 0; i < 2; i++ {
+			if _, err := client.Datasources.Create(cfg, false); err != nil {
+				t.Errorf("status %d: Create() error = %v", status, err)
+			}
+		}
+		if handlerCalls != 1 {
+			t.Errorf("status %d: catalog fetched %d times, want 1", status, handlerCalls)
+		}
+		srv.Close()
+	}
+}
+
+func TestDatasourceCreateRejectsInvalidConfig(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/handlers" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		w.Write([]byte(`[` + postgresHandlerJSON + `]`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", srv.URL)
+	_, err := client.Datasources.Create(&DatabaseConfig{Name: "ds", Engine: "postgres"}, false)
+	if !errors.As(err, new(*InvalidConfig)) {
+		t.Fatalf("Create() error = %v, want *InvalidConfig", err)
+	}
+}
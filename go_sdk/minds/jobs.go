@@ -0,0 +1,228 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// jobTimeLayout is the format job start and end times are sent in.
+const jobTimeLayout = "2006-01-02 15:04:05"
+
+// JobConfig represents the configuration for a scheduled job.
+type JobConfig struct {
+	Name string
+	// Query holds the SQL statements run on each execution.
+	Query string
+	// Every is the schedule interval, such as "hour" or "2 days", with or
+	// without a leading "every". An empty value runs the job once.
+	Every string
+	// StartAt and EndAt bound the schedule; nil means now and never.
+	StartAt *time.Time
+	EndAt   *time.Time
+	// IfQuery is a condition checked before each run; the job runs only
+	// when it returns rows.
+	IfQuery string
+}
+
+// Job represents a MindsDB scheduled job.
+type Job struct {
+	api         *RestAPI
+	Project     string `json:"project"`
+	Name        string `json:"name"`
+	Query       string `json:"query"`
+	IfQuery     string `json:"if_query"`
+	ScheduleStr string `json:"schedule_str"`
+	StartAt     string `json:"start_at"`
+	EndAt       string `json:"end_at"`
+	NextRunAt   string `json:"next_run_at"`
+}
+
+// JobRun is an entry of a job's execution history.
+type JobRun struct {
+	RunStart string `json:"run_start"`
+	RunEnd   string `json:"run_end"`
+	Query    string `json:"query"`
+	Error    string `json:"error"`
+}
+
+// History returns the job's past executions.
+func (j *Job) History() ([]*JobRun, error) {
+	return j.HistoryContext(context.Background())
+}
+
+// HistoryContext is like History with a context.
+func (j *Job) HistoryContext(ctx context.Context) (_ []*JobRun, err error) {
+	ctx, span := j.api._startSpan(ctx, "Job.History", AttrJob, j.Name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := j.api.get(ctx, fmt.Sprintf("/projects/%s/jobs/%s/history", j.Project, j.Name))
+	if err != nil {
+		return nil, fmt.Errorf("error getting job history: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var runs []*JobRun
+	if err := json.NewDecoder(resp.Body).Decode(&runs); err != nil {
+		return nil, fmt.Errorf("error decoding job history response: %w", err)
+	}
+	return runs, nil
+}
+
+// Jobs manages MindsDB scheduled jobs.
+type Jobs struct {
+	api     *RestAPI
+	project string
+}
+
+// NewJobs creates a new Jobs instance scoped to project.
+func NewJobs(client *Client, project string) *Jobs {
+	return &Jobs{
+		api:     client.api,
+		project: project,
+	}
+}
+
+// Create creates a new job.
+func (js *Jobs) Create(jobConfig *JobConfig, replace bool) (*Job, error) {
+	return js.CreateContext(context.Background(), jobConfig, replace)
+}
+
+// CreateContext is like Create with a context.
+func (js *Jobs) CreateContext(ctx context.Context, jobConfig *JobConfig, replace bool) (_ *Job, err error) {
+	ctx, span := js.api._startSpan(ctx, "Jobs.Create", AttrJob, jobConfig.Name)
+	defer func() { _endSpan(span, err) }()
+
+	if jobConfig.Query == "" {
+		return nil, &InvalidConfig{Message: fmt.Sprintf("job %s: Query is required", jobConfig.Name)}
+	}
+
+	if replace {
+		_, err := js.GetContext(ctx, jobConfig.Name)
+		if err == nil {
+			err = js.DropContext(ctx, jobConfig.Name)
+			if err != nil {
+				return nil, fmt.Errorf("error replacing job: %w", err)
+			}
+		} else if !errors.As(err, new(*ObjectNotFound)) {
+			return nil, fmt.Errorf("error checking for existing job: %w", err)
+		}
+	}
+
+	job := map[string]interface{}{
+		"name":  jobConfig.Name,
+		"query": jobConfig.Query,
+	}
+	if every := _scheduleInterval(jobConfig.Every); every != "" {
+		job["schedule_str"] = "every " + every
+	}
+	if jobConfig.StartAt != nil {
+		job["start_at"] = jobConfig.StartAt.UTC().Format(jobTimeLayout)
+	}
+	if jobConfig.EndAt != nil {
+		job["end_at"] = jobConfig.EndAt.UTC().Format(jobTimeLayout)
+	}
+	if jobConfig.IfQuery != "" {
+		job["if_query"] = jobConfig.IfQuery
+	}
+
+	resp, err := js.api.post(ctx, fmt.Sprintf("/projects/%s/jobs", js.project), map[string]interface{}{"job": job})
+	if err != nil {
+		return nil, fmt.Errorf("error creating job: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return js.GetContext(ctx, jobConfig.Name)
+}
+
+// _scheduleInterval trims the interval and a leading "every" from it.
+func _scheduleInterval(every string) string {
+	every = strings.TrimSpace(every)
+	if fields := strings.Fields(every); len(fields) > 0 && strings.EqualFold(fields[0], "every") {
+		every = strings.TrimSpace(every[len(fields[0]):])
+	}
+	return every
+}
+
+// List returns all jobs in the project.
+func (js *Jobs) List() ([]*Job, error) {
+	return js.ListContext(context.Background())
+}
+
+// ListContext is like List with a context.
+func (js *Jobs) ListContext(ctx context.Context) (_ []*Job, err error) {
+	ctx, span := js.api._startSpan(ctx, "Jobs.List")
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := js.api.get(ctx, fmt.Sprintf("/projects/%s/jobs", js.project))
+	if err != nil {
+		return nil, fmt.Errorf("error listing jobs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var data []*Job
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("error decoding jobs response: %w", err)
+	}
+	for _, job := range data {
+		job.api = js.api
+		job.Project = js.project
+	}
+	return data, nil
+}
+
+// Get retrieves a job by name.
+func (js *Jobs) Get(name string) (*Job, error) {
+	return js.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get with a context.
+func (js *Jobs) GetContext(ctx context.Context, name string) (_ *Job, err error) {
+	ctx, span := js.api._startSpan(ctx, "Jobs.Get", AttrJob, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := js.api.get(ctx, fmt.Sprintf("/projects/%s/jobs/%s", js.project, name))
+	if err != nil {
+		return nil, fmt.Errorf("error getting job: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var job Job
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		return nil, fmt.Errorf("error decoding job response: %w", err)
+	}
+	job.api = js.api
+	job.Project = js.project
+	return &job, nil
+}
+
+// Drop deletes a job by name.
+func (js *Jobs) Drop(name string) error {
+	return js.DropContext(context.Background(), name)
+}
+
+// DropContext is like Drop with a context.
+func (js *Jobs) DropContext(ctx context.Context, name string) (err error) {
+	ctx, span := js.api._startSpan(ctx, "Jobs.Drop", AttrJob, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := js.api.delete(ctx, fmt.Sprintf("/projects/%s/jobs/%s", js.project, name))
+	if err != nil {
+		return fmt.Errorf("error deleting job: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package minds
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJobsCreatePayload(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+	tests := []struct {
+		name    string
+		config  *JobConfig
+		wantJob map[string]interface{}
+	}{
+		{
+			name:    "run once",
+			config:  &JobConfig{Name: "once", Query: "SELECT 1"},
+			wantJob: map[string]interface{}{"name": "once", "query": "SELECT 1"},
+		},
+		{
+			name: "schedule, bounds and condition",
+			config: &JobConfig{
+				Name:    "nightly",
+				Query:   "INSERT INTO t SELECT * FROM s",
+				Every:   "2 days",
+				StartAt: &start,
+				IfQuery: "SELECT * FROM s LIMIT 1",
+			},
+			wantJob: map[string]interface{}{
+				"name":         "nightly",
+				"query":        "INSERT INTO t SELECT * FROM s",
+				"schedule_str": "every 2 days",
+				"start_at":     "2024-03-01 08:30:00",
+				"if_query":     "SELECT * FROM s LIMIT 1",
+			},
+		},
+		{
+			name:    "leading every",
+			config:  &JobConfig{Name: "hourly", Query: "SELECT 1", Every: " Every hour "},
+			wantJob: map[string]interface{}{"name": "hourly", "query": "SELECT 1", "schedule_str": "every hour"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := recordingServer(t, nil)
+			if _, err := NewJobs(NewClient("key", srv.URL), "sales").Create(tt.config, false); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			got := (*requests)[0]
+			want := recordedRequest{
+				Method: http.MethodPost,
+				Path:   "/api/projects/sales/jobs",
+				Body:   map[string]interface{}{"job": tt.wantJob},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("request = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestJobsCreateRequiresQuery(t *testing.T) {
+	_, err := NewJobs(NewClient("key", "http://127.0.0.1:1"), "sales").Create(&JobConfig{Name: "empty"}, false)
+	if _, ok := err.(*InvalidConfig); !ok {
+		t.Errorf("Create() error = %v, want InvalidConfig", err)
+	}
+}
@@ -0,0 +1,340 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// KnowledgeBaseConfig represents the configuration for a knowledge base.
+type KnowledgeBaseConfig struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Model is the embedding model used to index documents.
+	Model string `json:"model,omitempty"`
+	// Storage is the vector database table the embeddings are kept in.
+	Storage string                 `json:"storage,omitempty"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+}
+
+// KnowledgeBase represents a MindsDB knowledge base.
+type KnowledgeBase struct {
+	api         *RestAPI
+	Project     string                 `json:"project"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Model       string                 `json:"model"`
+	Storage     string                 `json:"storage"`
+	Params      map[string]interface{} `json:"params"`
+	CreatedAt   string                 `json:"created_at"`
+	UpdatedAt   string                 `json:"updated_at"`
+}
+
+// Document is a piece of text stored in a knowledge base.
+type Document struct {
+	ID       string                 `json:"id,omitempty"`
+	Content  string                 `json:"content"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// SearchResult is a document returned by a similarity query.
+type SearchResult struct {
+	ID       string                 `json:"id"`
+	Content  string                 `json:"content"`
+	Metadata map[string]interface{} `json:"metadata"`
+	// Distance between the query and the document; lower is more similar.
+	Distance float64 `json:"distance"`
+}
+
+// Insert adds documents to the knowledge base.
+func (kb *KnowledgeBase) Insert(documents []*Document) error {
+	return kb.InsertContext(context.Background(), documents)
+}
+
+// InsertContext is like Insert with a context.
+func (kb *KnowledgeBase) InsertContext(ctx context.Context, documents []*Document) (err error) {
+	ctx, span := kb.api._startSpan(ctx, "KnowledgeBase.Insert", AttrKnowledgeBase, kb.Name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := kb.api.put(
+		ctx,
+		fmt.Sprintf("/projects/%s/knowledge_bases/%s", kb.Project, kb.Name),
+		map[string]interface{}{
+			"knowledge_base": map[string]interface{}{"rows": documents},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting documents: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// Query returns up to limit documents most similar to query. A limit of 0
+// uses the server default.
+func (kb *KnowledgeBase) Query(query string, limit int) ([]*SearchResult, error) {
+	return kb.QueryContext(context.Background(), query, limit)
+}
+
+// QueryContext is like Query with a context.
+func (kb *KnowledgeBase) QueryContext(ctx context.Context, query string, limit int) (_ []*SearchResult, err error) {
+	ctx, span := kb.api._startSpan(ctx, "KnowledgeBase.Query", AttrKnowledgeBase, kb.Name)
+	defer func() { _endSpan(span, err) }()
+
+	data := map[string]interface{}{"query": query}
+	if limit > 0 {
+		data["limit"] = limit
+	}
+	resp, err := kb.api.post(ctx, fmt.Sprintf("/projects/%s/knowledge_bases/%s/query", kb.Project, kb.Name), data)
+	if err != nil {
+		return nil, fmt.Errorf("error querying knowledge base: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var results []*SearchResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("error decoding knowledge base query response: %w", err)
+	}
+	return results, nil
+}
+
+// KnowledgeBases manages MindsDB knowledge bases.
+type KnowledgeBases struct {
+	api     *RestAPI
+	project string
+}
+
+// NewKnowledgeBases creates a new KnowledgeBases instance scoped to project.
+func NewKnowledgeBases(client *Client, project string) *KnowledgeBases {
+	return &KnowledgeBases{
+		api:     client.api,
+		project: project,
+	}
+}
+
+// Create creates a new knowledge base.
+func (kbs *KnowledgeBases) Create(kbConfig *KnowledgeBaseConfig, replace bool) (*KnowledgeBase, error) {
+	return kbs.CreateContext(context.Background(), kbConfig, replace)
+}
+
+// CreateContext is like Create with a context.
+func (kbs *KnowledgeBases) CreateContext(ctx context.Context, kbConfig *KnowledgeBaseConfig, replace bool) (_ *KnowledgeBase, err error) {
+	ctx, span := kbs.api._startSpan(ctx, "KnowledgeBases.Create", AttrKnowledgeBase, kbConfig.Name)
+	defer func() { _endSpan(span, err) }()
+
+	if replace {
+		_, err := kbs.GetContext(ctx, kbConfig.Name)
+		if err == nil {
+			err = kbs.DropContext(ctx, kbConfig.Name)
+			if err != nil {
+				return nil, fmt.Errorf("error replacing knowledge base: %w", err)
+			}
+		} else if !errors.As(err, new(*ObjectNotFound)) {
+			return nil, fmt.Errorf("error checking for existing knowledge base: %w", err)
+		}
+	}
+
+	resp, err := kbs.api.post(
+		ctx,
+		fmt.Sprintf("/projects/%s/knowledge_bases", kbs.project),
+		map[string]interface{}{"knowledge_base": kbConfig},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating knowledge base: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return kbs.GetContext(ctx, kbConfig.Name)
+}
+
+// List returns all knowledge bases in the project.
+func (kbs *KnowledgeBases) List() ([]*KnowledgeBase, error) {
+	return kbs.ListContext(context.Background())
+}
+
+// ListContext is like List with a context.
+func (kbs *KnowledgeBases) ListContext(ctx context.Context) (_ []*KnowledgeBase, err error) {
+	ctx, span := kbs.api._startSpan(ctx, "KnowledgeBases.List")
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := kbs.api.get(ctx, fmt.Sprintf("/projects/%s/knowledge_bases", kbs.project))
+	if err != nil {
+		return nil, fmt.Errorf("error listing knowledge bases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var data []*KnowledgeBase
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("error decoding knowledge bases response: %w", err)
+	}
+	for _, kb := range data {
+		kb.api = kbs.api
+		kb.Project = kbs.project
+	}
+	return data, nil
+}
+
+// Get retrieves a knowledge base by name.
+func (kbs *KnowledgeBases) Get(name string) (*KnowledgeBase, error) {
+	return kbs.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get with a context.
+func (kbs *KnowledgeBases) GetContext(ctx context.Context, name string) (_ *KnowledgeBase, err error) {
+	ctx, span := kbs.api._startSpan(ctx, "KnowledgeBases.Get", AttrKnowledgeBase, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := kbs.api.get(ctx, fmt.Sprintf("/projects/%s/knowledge_bases/%s", kbs.project, name))
+	if err != nil {
+		return nil, fmt.Errorf("error getting knowledge base: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var kb KnowledgeBase
+	if err := json.NewDecoder(resp.Body).Decode(&kb); err != nil {
+		return nil, fmt.Errorf("error decoding knowledge base response: %w", err)
+	}
+	kb.api = kbs.api
+	kb.Project = kbs.project
+	return &kb, nil
+}
+
+// Drop deletes a knowledge base by name.
+func (kbs *KnowledgeBases) Drop(name string) error {
+	return kbs.DropContext(context.Background(), name)
+}
+
+// DropContext is like Drop with a context.
+func (kbs *KnowledgeBases) DropContext(ctx context.Context, name string) (err error) {
+	ctx, span := kbs.api._startSpan(ctx, "KnowledgeBases.Drop", AttrKnowledgeBase, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := kbs.api.delete(ctx, fmt.Sprintf("/projects/%s/knowledge_bases/%s", kbs.project, name))
+	if err != nil {
+		return fmt.Errorf("error deleting knowledge base: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (kbs *KnowledgeBases) _checkKnowledgeBase(ctx context.Context, kb interface{}) (string, error) {
+	switch kb := kb.(type) {
+	case string:
+		return kb, nil
+	case *KnowledgeBase:
+		if kb.Project != "" && kb.Project != kbs.project {
+			return kb.Project + "." + kb.Name, nil
+		}
+		return kb.Name, nil
+	case *KnowledgeBaseConfig:
+		if _, err := kbs.GetContext(ctx, kb.Name); err != nil {
+			if errors.As(err, new(*ObjectNotFound)) {
+				if _, err := kbs.CreateContext(ctx, kb, false); err != nil {
+					return "", fmt.Errorf("error creating knowledge base: %w", err)
+				}
+			} else {
+				return "", fmt.Errorf("error checking for existing knowledge base: %w", err)
+			}
+		}
+		return kb.Name, nil
+	default:
+		return "", fmt.Errorf("unknown knowledge base type: %T", kb)
+	}
+}
+
+// AddKnowledgeBase attaches a knowledge base to the mind. It accepts a name,
+// a *KnowledgeBase, or a *KnowledgeBaseConfig which is created in the mind's
+// project if missing.
+func (m *Mind) AddKnowledgeBase(knowledgeBase interface{}) error {
+	return m.AddKnowledgeBaseContext(context.Background(), knowledgeBase)
+}
+
+// AddKnowledgeBaseContext is like AddKnowledgeBase with a context.
+func (m *Mind) AddKnowledgeBaseContext(ctx context.Context, knowledgeBase interface{}) (err error) {
+	ctx, span := m.api._startSpan(ctx, "Mind.AddKnowledgeBase", AttrMind, m.Name)
+	defer func() { _endSpan(span, err) }()
+
+	kbName, err := NewKnowledgeBases(m.client, m.Project)._checkKnowledgeBase(ctx, knowledgeBase)
+	if err != nil {
+		return fmt.Errorf("error checking knowledge base: %w", err)
+	}
+	span.SetAttribute(AttrKnowledgeBase, kbName)
+
+	resp, err := m.api.post(
+		ctx,
+		fmt.Sprintf("/projects/%s/minds/%s/knowledge_bases", m.Project, m.Name),
+		map[string]string{"name": kbName},
+	)
+	if err != nil {
+		return fmt.Errorf("error adding knowledge base to mind: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	updatedMind, err := m.client.Minds.GetContext(ctx, m.Name)
+	if err != nil {
+		return fmt.Errorf("error getting updated mind: %w", err)
+	}
+	m.KnowledgeBases = updatedMind.KnowledgeBases
+	return nil
+}
+
+// DelKnowledgeBase detaches a knowledge base, given by name or object, from the mind.
+func (m *Mind) DelKnowledgeBase(knowledgeBase interface{}) error {
+	return m.DelKnowledgeBaseContext(context.Background(), knowledgeBase)
+}
+
+// DelKnowledgeBaseContext is like DelKnowledgeBase with a context.
+func (m *Mind) DelKnowledgeBaseContext(ctx context.Context, knowledgeBase interface{}) (err error) {
+	ctx, span := m.api._startSpan(ctx, "Mind.DelKnowledgeBase", AttrMind, m.Name)
+	defer func() { _endSpan(span, err) }()
+
+	var kbName string
+	switch kb := knowledgeBase.(type) {
+	case string:
+		kbName = kb
+	case *KnowledgeBase:
+		kbName = kb.Name
+	default:
+		return fmt.Errorf("unknown knowledge base type: %T", knowledgeBase)
+	}
+	span.SetAttribute(AttrKnowledgeBase, kbName)
+
+	resp, err := m.api.delete(
+		ctx,
+		fmt.Sprintf("/projects/%s/minds/%s/knowledge_bases/%s", m.Project, m.Name, kbName),
+	)
+	if err != nil {
+		return fmt.Errorf("error deleting knowledge base from mind: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	updatedMind, err := m.client.Minds.GetContext(ctx, m.Name)
+	if err != nil {
+		return fmt.Errorf("error getting updated mind: %w", err)
+	}
+	m.KnowledgeBases = updatedMind.KnowledgeBases
+	return nil
+}
@@ -0,0 +1,134 @@
+package minds
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// recordedRequest is a request received by a test server, with its JSON body.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Body   map[string]interface{}
+}
+
+// recordingServer answers every request with the response for its path, or
+// an empty object, and records the requests it receives.
+func recordingServer(t *testing.T, responses map[string]string) (*httptest.Server, *[]recordedRequest) {
+	t.Helper()
+	var requests []recordedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := recordedRequest{Method: r.Method, Path: r.URL.Path}
+		json.NewDecoder(r.Body).Decode(&req.Body)
+		requests = append(requests, req)
+		if response, ok := responses[r.Method+" "+r.URL.Path]; ok {
+			w.Write([]byte(response))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestKnowledgeBaseInsert(t *testing.T) {
+	srv, requests := recordingServer(t, map[string]string{
+		"GET /api/projects/sales/knowledge_bases/docs": `{"name": "docs"}`,
+	})
+	client := NewClient("key", srv.URL)
+	kb, err := NewKnowledgeBases(client, "sales").Get("docs")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	err = kb.Insert([]*Document{
+		{ID: "1", Content: "Refunds take 5 days.", Metadata: map[string]interface{}{"source": "faq"}},
+		{Content: "Shipping is free."},
+	})
+	if err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+
+	want := recordedRequest{
+		Method: http.MethodPut,
+		Path:   "/api/projects/sales/knowledge_bases/docs",
+		Body: map[string]interface{}{
+			"knowledge_base": map[string]interface{}{
+				"rows": []interface{}{
+					map[string]interface{}{"id": "1", "content": "Refunds take 5 days.", "metadata": map[string]interface{}{"source": "faq"}},
+					map[string]interface{}{"content": "Shipping is free."},
+				},
+			},
+		},
+	}
+	if got := (*requests)[len(*requests)-1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("request = %+v, want %+v", got, want)
+	}
+}
+
+func TestKnowledgeBaseQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		wantBody map[string]interface{}
+	}{
+		{name: "with limit", limit: 3, wantBody: map[string]interface{}{"query": "refunds", "limit": float64(3)}},
+		{name: "server default limit", limit: 0, wantBody: map[string]interface{}{"query": "refunds"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := recordingServer(t, map[string]string{
+				"POST /api/projects/sales/knowledge_bases/docs/query": `[{"id": "1", "content": "Refunds take 5 days.", "metadata": {"source": "faq"}, "distance": 0.25}]`,
+			})
+			kb := &KnowledgeBase{api: NewClient("key", srv.URL).api, Project: "sales", Name: "docs"}
+
+			results, err := kb.Query("refunds", tt.limit)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if got := (*requests)[0].Body; !reflect.DeepEqual(got, tt.wantBody) {
+				t.Errorf("body = %v, want %v", got, tt.wantBody)
+			}
+			want := []*SearchResult{{ID: "1", Content: "Refunds take 5 days.", Metadata: map[string]interface{}{"source": "faq"}, Distance: 0.25}}
+			if !reflect.DeepEqual(results, want) {
+				t.Errorf("results = %+v, want %+v", results[0], want[0])
+			}
+		})
+	}
+}
+
+func TestMindAddKnowledgeBaseUsesMindProject(t *testing.T) {
+	srv, requests := recordingServer(t, map[string]string{
+		"GET /api/projects/mindsdb/minds/m": `{"name": "m", "project": "sales"}`,
+	})
+	client := NewClient("key", srv.URL)
+	mind, err := client.Minds.Get("m")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	other := &KnowledgeBase{Project: "support", Name: "faq"}
+	if err := mind.AddKnowledgeBase(other); err != nil {
+		t.Fatalf("AddKnowledgeBase() error = %v", err)
+	}
+	got := (*requests)[len(*requests)-2]
+	want := recordedRequest{
+		Method: http.MethodPost,
+		Path:   "/api/projects/sales/minds/m/knowledge_bases",
+		Body:   map[string]interface{}{"name": "support.faq"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("request = %+v, want %+v", got, want)
+	}
+
+	// A config is looked up, and created if missing, in the mind's project.
+	if err := mind.AddKnowledgeBase(&KnowledgeBaseConfig{Name: "docs"}); err != nil {
+		t.Fatalf("AddKnowledgeBase() error = %v", err)
+	}
+	if got := (*requests)[len(*requests)-3].Path; got != "/api/projects/sales/knowledge_bases/docs" {
+		t.Errorf("lookup path = %q, want the mind's project", got)
+	}
+}
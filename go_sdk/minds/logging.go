@@ -0,0 +1,260 @@
+package minds
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// LogLevel is the severity of a log record.
+type LogLevel int
+
+// Log levels, from most to least verbose.
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// Logger receives log records from the client. Fields are alternating
+// key/value pairs.
+type Logger interface {
+	Log(level LogLevel, msg string, fields ...interface{})
+}
+
+// WithLogger sets the logger the client reports requests, retries, error
+// status mapping and stream lifecycle events to.
+func WithLogger(logger Logger) Option {
+	return func(r *RestAPI) {
+		r.Logger = logger
+	}
+}
+
+// WithDebugDump logs the headers and bodies of every request and response at
+// debug level. Credentials in headers and secret-looking JSON fields are
+// redacted; streamed and non-JSON bodies are not dumped.
+func WithDebugDump() Option {
+	return func(r *RestAPI) {
+		r.DebugDump = true
+	}
+}
+
+// StdLogger writes records with the standard library logger, as
+// "LEVEL msg key=value ...".
+type StdLogger struct {
+	Logger *log.Logger
+	// MinLevel drops records below this level.
+	MinLevel LogLevel
+}
+
+// NewStdLogger creates a StdLogger writing to w.
+func NewStdLogger(w io.Writer, minLevel LogLevel) *StdLogger {
+	return &StdLogger{
+		Logger:   log.New(w, "minds: ", log.LstdFlags),
+		MinLevel: minLevel,
+	}
+}
+
+// Log writes a record if its level is at least MinLevel.
+func (l *StdLogger) Log(level LogLevel, msg string, fields ...interface{}) {
+	if level < l.MinLevel {
+		return
+	}
+	var b strings.Builder
+	b.WriteString(level.String())
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for i := 0; i+1 < len(fields); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", fields[i], fields[i+1])
+	}
+	l.Logger.Print(b.String())
+}
+
+func (r *RestAPI) _log(level LogLevel, msg string, fields ...interface{}) {
+	if r.Logger != nil {
+		r.Logger.Log(level, msg, fields...)
+	}
+}
+
+// redactedHeaders are replaced in debug dumps.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// secretFields are substrings of JSON keys whose values are redacted in
+// debug dumps.
+var secretFields = []string{"password", "passwd", "secret", "token", "api_key", "apikey", "credential", "private_key"}
+
+// tokenCountFields are the token counts of completion requests and usage
+// reports, which match "token" but are not secrets.
+var tokenCountFields = map[string]bool{
+	"prompt_tokens":     true,
+	"completion_tokens": true,
+	"total_tokens":      true,
+	"max_tokens":        true,
+}
+
+// loggingTransport logs each request sent on the wire and, in debug mode,
+// dumps redacted headers and bodies.
+type loggingTransport struct {
+	api  *RestAPI
+	base http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := _apiPath(req.URL.Path)
+	if t.api.DebugDump {
+		t.api._log(LevelDebug, "request dump",
+			"method", req.Method,
+			"url", _redactURL(req.URL),
+			"headers", _redactHeaders(req.Header),
+			"body", _dumpRequestBody(req),
+		)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	duration := time.Since(start)
+	if err != nil {
+		t.api._log(LevelWarn, "request failed", "method", req.Method, "path", path, "duration", duration, "error", err)
+		return nil, err
+	}
+	t.api._log(LevelDebug, "request", "method", req.Method, "path", path, "status", resp.StatusCode, "duration", duration)
+
+	if t.api.DebugDump {
+		t.api._log(LevelDebug, "response dump",
+			"method", req.Method,
+			"path", path,
+			"status", resp.StatusCode,
+			"headers", _redactHeaders(resp.Header),
+			"body", _dumpResponseBody(resp),
+		)
+	}
+	return resp, nil
+}
+
+// _redactURL returns u with its query values and user info replaced, since
+// they may carry tokens.
+func _redactURL(u *url.URL) string {
+	redacted := *u
+	if redacted.User != nil {
+		redacted.User = url.User("REDACTED")
+	}
+	if redacted.RawQuery != "" {
+		query := redacted.Query()
+		for key := range query {
+			query[key] = []string{"REDACTED"}
+		}
+		redacted.RawQuery = query.Encode()
+	}
+	return redacted.String()
+}
+
+func _redactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "REDACTED")
+		}
+	}
+	return redacted
+}
+
+func _dumpRequestBody(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+	if req.GetBody == nil || !strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return "<omitted>"
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "<unavailable>"
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "<unavailable>"
+	}
+	return _redactJSON(data)
+}
+
+// _dumpResponseBody reads a JSON response body for logging and replaces it
+// so the caller can still read it.
+func _dumpResponseBody(resp *http.Response) string {
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return "<omitted>"
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return "<unavailable>"
+	}
+	return _redactJSON(data)
+}
+
+func _redactJSON(data []byte) string {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ""
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "<invalid json>"
+	}
+	redacted, err := json.Marshal(_redactValue(value))
+	if err != nil {
+		return "<invalid json>"
+	}
+	return string(redacted)
+}
+
+func _redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if _isSecretField(key) {
+				v[key] = "REDACTED"
+			} else {
+				v[key] = _redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = _redactValue(item)
+		}
+	}
+	return value
+}
+
+func _isSecretField(key string) bool {
+	key = strings.ToLower(key)
+	if tokenCountFields[key] {
+		return false
+	}
+	for _, field := range secretFields {
+		if strings.Contains(key, field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,253 @@
+package minds
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics receives measurements from the client.
+type Metrics interface {
+	// ObserveRequest records a REST or completion HTTP request. path is a
+	// template such as /projects/{project}/minds/{name}, and statusClass is
+	// "2xx", "4xx", "5xx" or "error" for transport failures.
+	ObserveRequest(method string, path string, statusClass string, duration time.Duration)
+	// ObserveOperation records an SDK operation such as Minds.Create.
+	ObserveOperation(operation string, failed bool, duration time.Duration)
+	// ObserveCompletion records a completion call. timeToFirstToken is zero
+	// for non-streamed completions, and chunks counts stream chunks.
+	ObserveCompletion(model string, stream bool, failed bool, timeToFirstToken time.Duration, duration time.Duration, chunks int)
+}
+
+// WithMetrics sets where the client reports metrics.
+func WithMetrics(metrics Metrics) Option {
+	return func(r *RestAPI) {
+		r.Metrics = metrics
+	}
+}
+
+// metricsSpan reports an operation's duration and outcome when it ends.
+type metricsSpan struct {
+	Span
+	metrics   Metrics
+	operation string
+	start     time.Time
+	failed    bool
+}
+
+func (s *metricsSpan) RecordError(err error) {
+	s.failed = true
+	s.Span.RecordError(err)
+}
+
+func (s *metricsSpan) End() {
+	s.metrics.ObserveOperation(s.operation, s.failed, time.Since(s.start))
+	s.Span.End()
+}
+
+// metricsTransport reports each HTTP request to the client's metrics.
+type metricsTransport struct {
+	api  *RestAPI
+	base http.RoundTripper
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	statusClass := "error"
+	if err == nil {
+		statusClass = fmt.Sprintf("%dxx", resp.StatusCode/100)
+	}
+	t.api.Metrics.ObserveRequest(req.Method, _pathTemplate(req.URL.Path), statusClass, time.Since(start))
+	return resp, err
+}
+
+// pathCollections maps path segments that are followed by an object name to
+// the placeholder used for that name.
+var pathCollections = map[string]string{
+	"projects":        "{project}",
+	"minds":           "{name}",
+	"datasources":     "{name}",
+	"knowledge_bases": "{name}",
+	"models":          "{name}",
+	"jobs":            "{name}",
+	"views":           "{name}",
+	"skills":          "{name}",
+	"agents":          "{name}",
+	"files":           "{name}",
+	"tables":          "{table}",
+}
+
+// pathActions are segments kept as is even where a name could appear.
+var pathActions = map[string]bool{
+	"test":    true,
+	"query":   true,
+	"predict": true,
+}
+
+// _pathTemplate replaces object names in an API path with placeholders to
+// keep metric cardinality bounded.
+func _pathTemplate(path string) string {
+	segments := strings.Split(strings.Trim(_apiPath(path), "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		placeholder, ok := pathCollections[segments[i-1]]
+		if ok && !pathActions[segments[i]] && !strings.HasPrefix(segments[i-1], "{") {
+			segments[i] = placeholder
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// latencyBuckets are the histogram bucket upper bounds, in seconds.
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (h *histogram) observe(d time.Duration) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(latencyBuckets))
+	}
+	seconds := d.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// InMemoryMetrics aggregates metrics in memory and exposes them in the
+// Prometheus text format.
+type InMemoryMetrics struct {
+	mu          sync.Mutex
+	requests    map[string]*histogram
+	operations  map[string]*histogram
+	completions map[string]*histogram
+	firstTokens map[string]*histogram
+	chunks      map[string]uint64
+}
+
+// NewInMemoryMetrics creates an empty InMemoryMetrics.
+func NewInMemoryMetrics() *InMemoryMetrics {
+	return &InMemoryMetrics{
+		requests:    make(map[string]*histogram),
+		operations:  make(map[string]*histogram),
+		completions: make(map[string]*histogram),
+		firstTokens: make(map[string]*histogram),
+		chunks:      make(map[string]uint64),
+	}
+}
+
+// ObserveRequest records an HTTP request.
+func (m *InMemoryMetrics) ObserveRequest(method string, path string, statusClass string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_observe(m.requests, _labels("method", method, "path", path, "status_class", statusClass), duration)
+}
+
+// ObserveOperation records an SDK operation.
+func (m *InMemoryMetrics) ObserveOperation(operation string, failed bool, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_observe(m.operations, _labels("operation", operation, "outcome", _outcome(failed)), duration)
+}
+
+// ObserveCompletion records a completion call.
+func (m *InMemoryMetrics) ObserveCompletion(model string, stream bool, failed bool, timeToFirstToken time.Duration, duration time.Duration, chunks int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_observe(m.completions, _labels("model", model, "stream", strconv.FormatBool(stream), "outcome", _outcome(failed)), duration)
+	if stream {
+		if timeToFirstToken > 0 {
+			_observe(m.firstTokens, _labels("model", model), timeToFirstToken)
+		}
+		m.chunks[_labels("model", model)] += uint64(chunks)
+	}
+}
+
+// WritePrometheus writes all metrics in the Prometheus text exposition format.
+func (m *InMemoryMetrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	_writeHistogram(&b, "minds_http_request_duration_seconds", "Duration of HTTP requests to the MindsDB API.", m.requests)
+	_writeHistogram(&b, "minds_operation_duration_seconds", "Duration of SDK operations.", m.operations)
+	_writeHistogram(&b, "minds_completion_duration_seconds", "Total duration of completion calls.", m.completions)
+	_writeHistogram(&b, "minds_completion_time_to_first_token_seconds", "Time until the first chunk of a streamed completion.", m.firstTokens)
+
+	b.WriteString("# HELP minds_completion_stream_chunks_total Chunks received from completion streams.\n")
+	b.WriteString("# TYPE minds_completion_stream_chunks_total counter\n")
+	for _, labels := range _sortedKeys(m.chunks) {
+		fmt.Fprintf(&b, "minds_completion_stream_chunks_total{%s} %d\n", labels, m.chunks[labels])
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Handler returns an http.Handler serving the metrics for Prometheus to scrape.
+func (m *InMemoryMetrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		m.WritePrometheus(w)
+	})
+}
+
+func _observe(histograms map[string]*histogram, labels string, d time.Duration) {
+	h, ok := histograms[labels]
+	if !ok {
+		h = &histogram{}
+		histograms[labels] = h
+	}
+	h.observe(d)
+}
+
+func _writeHistogram(b *strings.Builder, name string, help string, histograms map[string]*histogram) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s histogram\n", name)
+	for _, labels := range _sortedKeys(histograms) {
+		h := histograms[labels]
+		for i, bound := range latencyBuckets {
+			fmt.Fprintf(b, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(b, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, h.count)
+		fmt.Fprintf(b, "%s_sum{%s} %s\n", name, labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(b, "%s_count{%s} %d\n", name, labels, h.count)
+	}
+}
+
+// _labels formats key/value pairs as Prometheus labels.
+func _labels(pairs ...string) string {
+	labels := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		labels = append(labels, fmt.Sprintf("%s=%s", pairs[i], strconv.Quote(pairs[i+1])))
+	}
+	return strings.Join(labels, ",")
+}
+
+func _outcome(failed bool) string {
+	if failed {
+		return "error"
+	}
+	return "success"
+}
+
+func _sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,87 @@
+package minds
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPathTemplate(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/api/minds", "/minds"},
+		{"/api/minds/sales", "/minds/{name}"},
+		{"/api/projects/mindsdb/models/rentals/predict", "/projects/{project}/models/{name}/predict"},
+		{"/api/projects/mindsdb/agents/bot/completions/stream", "/projects/{project}/agents/{name}/completions/stream"},
+		{"/api/datasources/db/tables/orders", "/datasources/{name}/tables/{table}"},
+		{"/api/datasources/db/test", "/datasources/{name}/test"},
+		{"/api/projects/mindsdb/knowledge_bases/docs/query", "/projects/{project}/knowledge_bases/{name}/query"},
+	}
+	for _, tt := range tests {
+		if got := _pathTemplate(tt.path); got != tt.want {
+			t.Errorf("_pathTemplate(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestHistogramBuckets(t *testing.T) {
+	var h histogram
+	h.observe(3 * time.Millisecond)
+	h.observe(200 * time.Millisecond)
+	h.observe(2 * time.Minute)
+
+	tests := []struct {
+		bound float64
+		want  uint64
+	}{
+		{0.005, 1},
+		{0.1, 1},
+		{0.25, 2},
+		{60, 2},
+	}
+	for _, tt := range tests {
+		for i, bound := range latencyBuckets {
+			if bound == tt.bound && h.counts[i] != tt.want {
+				t.Errorf("bucket le=%v = %d, want %d", tt.bound, h.counts[i], tt.want)
+			}
+		}
+	}
+	if h.count != 3 {
+		t.Errorf("count = %d, want 3", h.count)
+	}
+}
+
+func TestInMemoryMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"name": "s", "type": "text2sql", "params": {}}`))
+	}))
+	defer srv.Close()
+
+	metrics := NewInMemoryMetrics()
+	client := NewClient("key", srv.URL, WithMetrics(metrics))
+	client.Skills.Get("s")
+	client.Skills.Get("missing")
+
+	var b strings.Builder
+	if err := metrics.WritePrometheus(&b); err != nil {
+		t.Fatalf("WritePrometheus() error = %v", err)
+	}
+	for _, want := range []string{
+		`minds_http_request_duration_seconds_count{method="GET",path="/projects/{project}/skills/{name}",status_class="2xx"} 1`,
+		`minds_http_request_duration_seconds_count{method="GET",path="/projects/{project}/skills/{name}",status_class="4xx"} 1`,
+		`minds_operation_duration_seconds_count{operation="Skills.Get",outcome="success"} 1`,
+		`minds_operation_duration_seconds_count{operation="Skills.Get",outcome="error"} 1`,
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("metrics do not contain %s\n%s", want, b.String())
+		}
+	}
+}
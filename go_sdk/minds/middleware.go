@@ -0,0 +1,52 @@
+package minds
+
+import "net/http"
+
+// Middleware wraps the transport used for every request the client sends,
+// REST and completion traffic alike. It can inspect or modify requests and
+// responses, for example to add tracing headers or audit calls.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware adds middleware to the client. The first middleware given
+// is the outermost: it sees requests first and responses last. Middleware
+// runs before authentication, so requests it sees carry no credentials.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(r *RestAPI) {
+		r.Middleware = append(r.Middleware, middleware...)
+	}
+}
+
+// HeaderMiddleware sets the given headers on every request, such as a
+// tenant ID.
+func HeaderMiddleware(headers http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for key, values := range headers {
+				// Keys are canonicalized, so a lowercase key replaces the
+				// header rather than being sent alongside it.
+				req.Header.Del(key)
+				for _, value := range values {
+					req.Header.Add(key, value)
+				}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// _chain wraps transport in middleware, the first middleware outermost.
+func _chain(middleware []Middleware, transport http.RoundTripper) http.RoundTripper {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+	return transport
+}
@@ -0,0 +1,51 @@
+package minds
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestHeaderMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers http.Header
+		want    http.Header
+	}{
+		{
+			name:    "canonical key",
+			headers: http.Header{"X-Tenant": {"acme"}},
+			want:    http.Header{"X-Tenant": {"acme"}, "Accept": {"application/json"}},
+		},
+		{
+			name:    "lowercase key replaces existing header",
+			headers: http.Header{"accept": {"text/plain"}},
+			want:    http.Header{"Accept": {"text/plain"}},
+		},
+		{
+			name:    "multiple values",
+			headers: http.Header{"x-tag": {"a", "b"}},
+			want:    http.Header{"X-Tag": {"a", "b"}, "Accept": {"application/json"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got http.Header
+			next := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				got = req.Header
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+			})
+			req, _ := http.NewRequest("GET", "http://localhost/api/minds", nil)
+			req.Header.Set("Accept", "application/json")
+			if _, err := HeaderMiddleware(tt.headers)(next).RoundTrip(req); err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("headers = %v, want %v", got, tt.want)
+			}
+			if req.Header.Get("Accept") != "application/json" {
+				t.Error("the caller's request was modified")
+			}
+		})
+	}
+}
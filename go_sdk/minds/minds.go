@@ -3,12 +3,11 @@ package minds
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-
-	openai "github.com/sashabaranov/go-openai"
 )
 
 const DEFAULT_PROMPT_TEMPLATE = "{{input}}"
@@ -24,24 +23,70 @@ type Mind struct {
 	PromptTemplate string                 `json:"prompt_template"`
 	Parameters     map[string]interface{} `json:"parameters"`
 	Datasources    []string               `json:"datasources"`
+	KnowledgeBases []string               `json:"knowledge_bases"`
 	CreatedAt      string                 `json:"created_at"`
 	UpdatedAt      string                 `json:"updated_at"`
+	tag            string
+	// datasources keeps the table scoping of Datasources.
+	datasources []*mindDatasource
+}
+
+// UnmarshalJSON accepts datasources given by name or as objects with tables.
+func (m *Mind) UnmarshalJSON(data []byte) error {
+	type plain Mind
+	raw := struct {
+		*plain
+		Datasources []*mindDatasource `json:"datasources"`
+	}{plain: (*plain)(m)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	m.datasources = raw.Datasources
+	m.Datasources = make([]string, 0, len(raw.Datasources))
+	for _, ds := range raw.Datasources {
+		m.Datasources = append(m.Datasources, ds.Name)
+	}
+	return nil
+}
+
+// Tagged returns a copy of the mind whose completions are accounted to tag.
+func (m *Mind) Tagged(tag string) *Mind {
+	tagged := *m
+	tagged.tag = tag
+	return &tagged
 }
 
 // Update updates a Mind's configuration.
 func (m *Mind) Update(updateOpts *UpdateMindOptions) error {
+	return m.UpdateContext(context.Background(), updateOpts)
+}
+
+// UpdateContext is like Update with a context.
+func (m *Mind) UpdateContext(ctx context.Context, updateOpts *UpdateMindOptions) (err error) {
+	ctx, span := m.api._startSpan(ctx, "Mind.Update", AttrMind, m.Name)
+	defer func() { _endSpan(span, err) }()
+
+	if updateOpts.ModelName != nil || updateOpts.Provider != nil {
+		provider, modelName := m.Provider, m.ModelName
+		if updateOpts.Provider != nil {
+			provider = *updateOpts.Provider
+		}
+		if updateOpts.ModelName != nil {
+			modelName = *updateOpts.ModelName
+		}
+		if err := m.client.providers.validate(ctx, provider, modelName); err != nil {
+			return fmt.Errorf("error validating model: %w", err)
+		}
+	}
+
 	data := make(map[string]interface{})
 
 	if updateOpts.Datasources != nil {
-		dsNames := make([]string, 0, len(updateOpts.Datasources))
-		for _, ds := range updateOpts.Datasources {
-			dsName, err := m.client.Minds._checkDatasource(ds)
-			if err != nil {
-				return fmt.Errorf("error checking datasource: %w", err)
-			}
-			dsNames = append(dsNames, dsName)
+		dsRefs, err := m.client.Minds._checkDatasources(ctx, updateOpts.Datasources)
+		if err != nil {
+			return err
 		}
-		data["datasources"] = dsNames
+		data["datasources"] = dsRefs
 	}
 
 	if updateOpts.Name != nil {
@@ -63,7 +108,7 @@ func (m *Mind) Update(updateOpts *UpdateMindOptions) error {
 	}
 	data["parameters"] = parameters
 
-	resp, err := m.api.patch(fmt.Sprintf("/projects/%s/minds/%s", m.Project, m.Name), data)
+	resp, err := m.api.patch(ctx, fmt.Sprintf("/projects/%s/minds/%s", m.Project, m.Name), data)
 	if err != nil {
 		return fmt.Errorf("error updating mind: %w", err)
 	}
@@ -89,14 +134,24 @@ type UpdateMindOptions struct {
 }
 
 func (m *Mind) AddDatasource(datasource interface{}) error {
-	dsName, err := m.client.Minds._checkDatasource(datasource)
+	return m.AddDatasourceContext(context.Background(), datasource)
+}
+
+// AddDatasourceContext is like AddDatasource with a context.
+func (m *Mind) AddDatasourceContext(ctx context.Context, datasource interface{}) (err error) {
+	ctx, span := m.api._startSpan(ctx, "Mind.AddDatasource", AttrMind, m.Name)
+	defer func() { _endSpan(span, err) }()
+
+	dsRef, err := m.client.Minds._checkDatasource(ctx, datasource)
 	if err != nil {
 		return fmt.Errorf("error checking datasource: %w", err)
 	}
+	span.SetAttribute(AttrDatasource, dsRef.Name)
 
 	resp, err := m.api.post(
+		ctx,
 		fmt.Sprintf("/projects/%s/minds/%s/datasources", m.Project, m.Name),
-		map[string]string{"name": dsName},
+		dsRef,
 	)
 	if err != nil {
 		return fmt.Errorf("error adding datasource to mind: %w", err)
@@ -107,27 +162,46 @@ func (m *Mind) AddDatasource(datasource interface{}) error {
 		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
 	}
 
-	updatedMind, err := m.client.Minds.Get(m.Name)
+	updatedMind, err := m.client.Minds.GetContext(ctx, m.Name)
 	if err != nil {
 		return fmt.Errorf("error getting updated mind: %w", err)
 	}
 	m.Datasources = updatedMind.Datasources
+	m.datasources = updatedMind.datasources
 	return nil
 }
 
+// DelDatasource removes a datasource, given by name, *Datasource, *File or
+// *View, from the mind. Removing a *File only removes its table from the
+// files datasource.
 func (m *Mind) DelDatasource(datasource interface{}) error {
+	return m.DelDatasourceContext(context.Background(), datasource)
+}
+
+// DelDatasourceContext is like DelDatasource with a context.
+func (m *Mind) DelDatasourceContext(ctx context.Context, datasource interface{}) (err error) {
+	ctx, span := m.api._startSpan(ctx, "Mind.DelDatasource", AttrMind, m.Name)
+	defer func() { _endSpan(span, err) }()
+
 	var dsName string
 	switch ds := datasource.(type) {
 	case string:
 		dsName = ds
 	case *Datasource:
 		dsName = ds.Name
+	case *File:
+		span.SetAttribute(AttrDatasource, FilesDatasource)
+		return m._delFile(ctx, ds.Name)
+	case *View:
+		dsName = ds.Project + "." + ds.Name
 	default:
 		return fmt.Errorf("unknown datasource type: %T", datasource)
 	}
+	span.SetAttribute(AttrDatasource, dsName)
 
 	resp, err := m.api.delete(
-		fmt.Sprintf("/projects/%s/minds/%s/datasources/%s", m.Project, m.Name, dsName),
+		ctx,
+		fmt.Sprintf("/projects/%s/minds/%s/datasources/%s", m.Project, m.Name, url.PathEscape(dsName)),
 	)
 	if err != nil {
 		return fmt.Errorf("error deleting datasource from mind: %w", err)
@@ -138,76 +212,78 @@ func (m *Mind) DelDatasource(datasource interface{}) error {
 		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
 	}
 
-	updatedMind, err := m.client.Minds.Get(m.Name)
+	updatedMind, err := m.client.Minds.GetContext(ctx, m.Name)
 	if err != nil {
 		return fmt.Errorf("error getting updated mind: %w", err)
 	}
 	m.Datasources = updatedMind.Datasources
+	m.datasources = updatedMind.datasources
 	return nil
 }
 
-func (m *Mind) Completion(message string, useStream bool) (string, error) {
-	parsedURL, err := url.Parse(m.api.BaseURL)
-	if err != nil {
-		return "", fmt.Errorf("error parsing API base URL: %w", err)
+// _delFile removes the file's table from the mind's files datasource, and the
+// datasource itself once no tables are left.
+func (m *Mind) _delFile(ctx context.Context, name string) error {
+	dsRefs := make([]*mindDatasource, 0, len(m.datasources))
+	found := false
+	for _, ds := range m.datasources {
+		if ds.Name != FilesDatasource {
+			dsRefs = append(dsRefs, ds)
+			continue
+		}
+		tables := make([]string, 0, len(ds.Tables))
+		for _, table := range ds.Tables {
+			if table == name {
+				found = true
+				continue
+			}
+			tables = append(tables, table)
+		}
+		if len(tables) > 0 {
+			dsRefs = append(dsRefs, &mindDatasource{Name: FilesDatasource, Tables: tables})
+		}
 	}
-
-	var llmHost string
-	if parsedURL.Host == "mdb.ai" {
-		llmHost = "llm.mdb.ai"
-	} else {
-		llmHost = "ai." + parsedURL.Host
+	if !found {
+		return &ObjectNotFound{Message: fmt.Sprintf("file %s is not a table of mind %s", name, m.Name)}
 	}
 
-	parsedURL.Host = llmHost
-	parsedURL.Path = ""
+	data := map[string]interface{}{
+		"datasources": _datasourcePayload(dsRefs),
+	}
+	resp, err := m.api.patch(ctx, fmt.Sprintf("/projects/%s/minds/%s", m.Project, m.Name), data)
+	if err != nil {
+		return fmt.Errorf("error deleting file from mind: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
 
-	clientConfig := openai.DefaultConfig(m.api.APIKey)
-	clientConfig.BaseURL = parsedURL.String()
-	openAIClient := openai.NewClientWithConfig(clientConfig)
+	updatedMind, err := m.client.Minds.GetContext(ctx, m.Name)
+	if err != nil {
+		return fmt.Errorf("error getting updated mind: %w", err)
+	}
+	m.Datasources = updatedMind.Datasources
+	m.datasources = updatedMind.datasources
+	return nil
+}
 
-	ctx := context.Background()
+func (m *Mind) Completion(message string, useStream bool) (string, error) {
+	return m.CompletionContext(context.Background(), message, useStream)
+}
 
-	if useStream {
-		stream, err := openAIClient.CreateChatCompletionStream(
-			ctx,
-			openai.ChatCompletionRequest{
-				Model:    m.Name,
-				Messages: []openai.ChatCompletionMessage{{Role: "user", Content: message}},
-				Stream:   true,
-			},
-		)
-		if err != nil {
-			return "", fmt.Errorf("error creating chat completion stream: %w", err)
-		}
-		defer stream.Close()
+// CompletionContext is like Completion with a context.
+func (m *Mind) CompletionContext(ctx context.Context, message string, useStream bool) (_ string, err error) {
+	ctx, span := m.api._startSpan(ctx, "Mind.Completion", AttrMind, m.Name, AttrStream, useStream)
+	defer func() { _endSpan(span, err) }()
 
-		var fullResponse string
-		for {
-			response, err := stream.Recv()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return "", fmt.Errorf("error receiving chat completion stream: %w", err)
-			}
-			fullResponse += response.Choices[0].Delta.Content
-		}
-		return fullResponse, nil
-	} else {
-		response, err := openAIClient.CreateChatCompletion(
-			ctx,
-			openai.ChatCompletionRequest{
-				Model:    m.Name,
-				Messages: []openai.ChatCompletionMessage{{Role: "user", Content: message}},
-				Stream:   false,
-			},
-		)
-		if err != nil {
-			return "", fmt.Errorf("error creating chat completion: %w", err)
-		}
-		return response.Choices[0].Message.Content, nil
+	llmURL, err := m.api._llmURL()
+	if err != nil {
+		return "", err
 	}
+	ctx = _withUsageLabels(ctx, m.ModelName, m.tag)
+	return _complete(ctx, m.api, llmURL, m.Name, message, useStream)
 }
 
 type Minds struct {
@@ -225,7 +301,15 @@ func NewMinds(client *Client) *Minds {
 }
 
 func (ms *Minds) List() ([]*Mind, error) {
-	resp, err := ms.api.get(fmt.Sprintf("/projects/%s/minds", ms.project))
+	return ms.ListContext(context.Background())
+}
+
+// ListContext is like List with a context.
+func (ms *Minds) ListContext(ctx context.Context) (_ []*Mind, err error) {
+	ctx, span := ms.api._startSpan(ctx, "Minds.List")
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := ms.api.get(ctx, fmt.Sprintf("/projects/%s/minds", ms.project))
 	if err != nil {
 		return nil, fmt.Errorf("error listing minds: %w", err)
 	}
@@ -236,13 +320,22 @@ func (ms *Minds) List() ([]*Mind, error) {
 		return nil, fmt.Errorf("error decoding minds response: %w", err)
 	}
 	for _, mind := range data {
+		mind.api = ms.api
 		mind.client = ms.client
 	}
 	return data, nil
 }
 
 func (ms *Minds) Get(name string) (*Mind, error) {
-	resp, err := ms.api.get(fmt.Sprintf("/projects/%s/minds/%s", ms.project, name))
+	return ms.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get with a context.
+func (ms *Minds) GetContext(ctx context.Context, name string) (_ *Mind, err error) {
+	ctx, span := ms.api._startSpan(ctx, "Minds.Get", AttrMind, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := ms.api.get(ctx, fmt.Sprintf("/projects/%s/minds/%s", ms.project, name))
 	if err != nil {
 		return nil, fmt.Errorf("error getting mind: %w", err)
 	}
@@ -253,29 +346,105 @@ func (ms *Minds) Get(name string) (*Mind, error) {
 		return nil, fmt.Errorf("error decoding mind response: %w", err)
 	}
 
+	mind.api = ms.api
 	mind.client = ms.client
 	return &mind, nil
 }
 
-func (ms *Minds) _checkDatasource(ds interface{}) (string, error) {
+// mindDatasource references a datasource in a mind's configuration. When
+// Tables is set, the mind can only use those tables.
+type mindDatasource struct {
+	Name   string   `json:"name"`
+	Tables []string `json:"tables,omitempty"`
+}
+
+// UnmarshalJSON accepts a datasource given by name as well as an object.
+func (d *mindDatasource) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*d = mindDatasource{Name: name}
+		return nil
+	}
+	type plain mindDatasource
+	return json.Unmarshal(data, (*plain)(d))
+}
+
+// _checkDatasources resolves the datasources of a mind's configuration into
+// the payload sent to the server.
+func (ms *Minds) _checkDatasources(ctx context.Context, dss []interface{}) ([]interface{}, error) {
+	dsRefs := make([]*mindDatasource, 0, len(dss))
+	for _, ds := range dss {
+		dsRef, err := ms._checkDatasource(ctx, ds)
+		if err != nil {
+			return nil, fmt.Errorf("error checking datasource: %w", err)
+		}
+		dsRefs = append(dsRefs, dsRef)
+	}
+	return _datasourcePayload(dsRefs), nil
+}
+
+// _datasourcePayload merges references to the same datasource, such as
+// several files, into one entry. Entries without tables are sent by name
+// alone; a datasource referenced without tables anywhere is used whole.
+func _datasourcePayload(dsRefs []*mindDatasource) []interface{} {
+	merged := make([]*mindDatasource, 0, len(dsRefs))
+	byName := make(map[string]*mindDatasource)
+	for _, dsRef := range dsRefs {
+		prev, ok := byName[dsRef.Name]
+		if !ok {
+			entry := &mindDatasource{Name: dsRef.Name, Tables: append([]string(nil), dsRef.Tables...)}
+			byName[dsRef.Name] = entry
+			merged = append(merged, entry)
+			continue
+		}
+		if len(prev.Tables) == 0 || len(dsRef.Tables) == 0 {
+			prev.Tables = nil
+			continue
+		}
+		for _, table := range dsRef.Tables {
+			if !_containsString(prev.Tables, table) {
+				prev.Tables = append(prev.Tables, table)
+			}
+		}
+	}
+
+	payload := make([]interface{}, 0, len(merged))
+	for _, entry := range merged {
+		if len(entry.Tables) == 0 {
+			payload = append(payload, entry.Name)
+		} else {
+			payload = append(payload, entry)
+		}
+	}
+	return payload
+}
+
+func (ms *Minds) _checkDatasource(ctx context.Context, ds interface{}) (*mindDatasource, error) {
 	switch ds := ds.(type) {
 	case string:
-		return ds, nil
+		return &mindDatasource{Name: ds}, nil
 	case *Datasource:
-		return ds.Name, nil
+		return &mindDatasource{Name: ds.Name, Tables: ds.DatabaseConfig.Tables}, nil
+	case *File:
+		// Only the file's own table of the files datasource is exposed.
+		return &mindDatasource{Name: FilesDatasource, Tables: []string{ds.Name}}, nil
+	case *View:
+		// Views are referenced by qualified name; servers without view
+		// support reject it when the mind is saved.
+		return &mindDatasource{Name: ds.Project + "." + ds.Name}, nil
 	case DatabaseConfig:
-		if _, err := ms.client.Datasources.Get(ds.Name); err != nil {
-			if _, ok := err.(*ObjectNotFound); ok {
-				if _, err := ms.client.Datasources.Create(&ds, false); err != nil {
-					return "", fmt.Errorf("error creating datasource: %w", err)
+		if _, err := ms.client.Datasources.GetContext(ctx, ds.Name); err != nil {
+			if errors.As(err, new(*ObjectNotFound)) {
+				if _, err := ms.client.Datasources.CreateContext(ctx, &ds, false); err != nil {
+					return nil, fmt.Errorf("error creating datasource: %w", err)
 				}
 			} else {
-				return "", fmt.Errorf("error checking for existing datasource: %w", err)
+				return nil, fmt.Errorf("error checking for existing datasource: %w", err)
 			}
 		}
-		return ds.Name, nil
+		return &mindDatasource{Name: ds.Name, Tables: ds.Tables}, nil
 	default:
-		return "", fmt.Errorf("unknown datasource type: %T", ds)
+		return nil, fmt.Errorf("unknown datasource type: %T", ds)
 	}
 }
 
@@ -288,14 +457,35 @@ type CreateMindOptions struct {
 }
 
 func (ms *Minds) Create(name string, opts *CreateMindOptions, replace bool) (*Mind, error) {
+	return ms.CreateContext(context.Background(), name, opts, replace)
+}
+
+// CreateContext is like Create with a context.
+func (ms *Minds) CreateContext(ctx context.Context, name string, opts *CreateMindOptions, replace bool) (_ *Mind, err error) {
+	ctx, span := ms.api._startSpan(ctx, "Minds.Create", AttrMind, name)
+	defer func() { _endSpan(span, err) }()
+
+	if opts != nil && (opts.ModelName != nil || opts.Provider != nil) {
+		var provider, modelName string
+		if opts.Provider != nil {
+			provider = *opts.Provider
+		}
+		if opts.ModelName != nil {
+			modelName = *opts.ModelName
+		}
+		if err := ms.client.providers.validate(ctx, provider, modelName); err != nil {
+			return nil, fmt.Errorf("error validating model: %w", err)
+		}
+	}
+
 	if replace {
-		_, err := ms.Get(name)
+		_, err := ms.GetContext(ctx, name)
 		if err == nil {
-			err = ms.Drop(name)
+			err = ms.DropContext(ctx, name)
 			if err != nil {
 				return nil, fmt.Errorf("error replacing mind: %w", err)
 			}
-		} else if _, ok := err.(*ObjectNotFound); !ok {
+		} else if !errors.As(err, new(*ObjectNotFound)) {
 			return nil, fmt.Errorf("error checking for existing mind: %w", err)
 		}
 	}
@@ -313,15 +503,11 @@ func (ms *Minds) Create(name string, opts *CreateMindOptions, replace bool) (*Mi
 		}
 
 		if opts.Datasources != nil {
-			dsNames := make([]string, 0, len(opts.Datasources))
-			for _, ds := range opts.Datasources {
-				dsName, err := ms._checkDatasource(ds)
-				if err != nil {
-					return nil, fmt.Errorf("error checking datasource: %w", err)
-				}
-				dsNames = append(dsNames, dsName)
+			dsRefs, err := ms._checkDatasources(ctx, opts.Datasources)
+			if err != nil {
+				return nil, err
 			}
-			data["datasources"] = dsNames
+			data["datasources"] = dsRefs
 		}
 
 		parameters := make(map[string]interface{})
@@ -340,7 +526,7 @@ func (ms *Minds) Create(name string, opts *CreateMindOptions, replace bool) (*Mi
 		}
 	}
 
-	resp, err := ms.api.post(fmt.Sprintf("/projects/%s/minds", ms.project), data)
+	resp, err := ms.api.post(ctx, fmt.Sprintf("/projects/%s/minds", ms.project), data)
 	if err != nil {
 		return nil, fmt.Errorf("error creating mind: %w", err)
 	}
@@ -350,11 +536,19 @@ func (ms *Minds) Create(name string, opts *CreateMindOptions, replace bool) (*Mi
 		body, _ := io.ReadAll(resp.Body)
 		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
 	}
-	return ms.Get(name)
+	return ms.GetContext(ctx, name)
 }
 
 func (ms *Minds) Drop(name string) error {
-	resp, err := ms.api.delete(fmt.Sprintf("/projects/%s/minds/%s", ms.project, name))
+	return ms.DropContext(context.Background(), name)
+}
+
+// DropContext is like Drop with a context.
+func (ms *Minds) DropContext(ctx context.Context, name string) (err error) {
+	ctx, span := ms.api._startSpan(ctx, "Minds.Drop", AttrMind, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := ms.api.delete(ctx, fmt.Sprintf("/projects/%s/minds/%s", ms.project, name))
 	if err != nil {
 		return fmt.Errorf("error deleting mind: %w", err)
 	}
@@ -365,3 +559,12 @@ func (ms *Minds) Drop(name string) error {
 	}
 	return nil
 }
+
+func _containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
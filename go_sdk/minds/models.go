@@ -0,0 +1,357 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"go_sdk/internal/sqlvalue"
+)
+
+// Model training statuses reported by the server.
+const (
+	ModelStatusGenerating = "generating"
+	ModelStatusTraining   = "training"
+	ModelStatusComplete   = "complete"
+	ModelStatusError      = "error"
+)
+
+// Model represents a MindsDB ML model (predictor).
+type Model struct {
+	client          *Client
+	Project         string                 `json:"project"`
+	Name            string                 `json:"name"`
+	Version         int                    `json:"version"`
+	Active          bool                   `json:"active"`
+	Status          string                 `json:"status"`
+	Target          string                 `json:"predict"`
+	Engine          string                 `json:"engine"`
+	Accuracy        *float64               `json:"accuracy"`
+	Error           string                 `json:"error"`
+	FetchDataQuery  string                 `json:"fetch_data_query"`
+	TrainingOptions map[string]interface{} `json:"training_options"`
+	CreatedAt       string                 `json:"created_at"`
+	UpdatedAt       string                 `json:"updated_at"`
+}
+
+// CreateModelOptions describes how a model is trained.
+type CreateModelOptions struct {
+	// Predict is the target column.
+	Predict string
+	// Datasource is the integration Select runs against. Leave empty when
+	// Select uses fully qualified table names.
+	Datasource string
+	// Select is the SQL query providing the training data.
+	Select string
+	// Engine is the ML engine; the server default is used when empty.
+	Engine string
+	// Using holds additional engine parameters.
+	Using map[string]interface{}
+}
+
+// RetrainModelOptions overrides the training data or parameters on retrain.
+// Empty fields keep the values the model was trained with.
+type RetrainModelOptions struct {
+	Datasource string
+	Select     string
+	Using      map[string]interface{}
+}
+
+// Refresh reloads the model's status and metadata from the server.
+func (m *Model) Refresh() error {
+	return m.RefreshContext(context.Background())
+}
+
+// RefreshContext is like Refresh with a context.
+func (m *Model) RefreshContext(ctx context.Context) error {
+	updated, err := m.client.Models._getInProject(ctx, m.Project, m.Name)
+	if err != nil {
+		return err
+	}
+	*m = *updated
+	return nil
+}
+
+// WaitUntilTrained polls the model every interval until training completes.
+// A failed training is returned as TrainingFailed.
+func (m *Model) WaitUntilTrained(ctx context.Context, interval time.Duration) (err error) {
+	ctx, span := m.client.api._startSpan(ctx, "Model.WaitUntilTrained", AttrModel, m.Name)
+	defer func() { _endSpan(span, err) }()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		switch m.Status {
+		case ModelStatusComplete:
+			return nil
+		case ModelStatusError:
+			return &TrainingFailed{Message: fmt.Sprintf("%s: %s", m.Name, m.Error)}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if err := m.RefreshContext(ctx); err != nil {
+			return fmt.Errorf("error polling model status: %w", err)
+		}
+	}
+}
+
+// Describe returns the model's description rows, such as feature and
+// accuracy information, as reported by the engine.
+func (m *Model) Describe() ([]map[string]interface{}, error) {
+	return m.DescribeContext(context.Background())
+}
+
+// DescribeContext is like Describe with a context.
+func (m *Model) DescribeContext(ctx context.Context) (_ []map[string]interface{}, err error) {
+	ctx, span := m.client.api._startSpan(ctx, "Model.Describe", AttrModel, m.Name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := m.client.api.get(ctx, fmt.Sprintf("/projects/%s/models/%s/describe", m.Project, m.Name))
+	if err != nil {
+		return nil, fmt.Errorf("error describing model: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var data []map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("error decoding model description: %w", err)
+	}
+	return data, nil
+}
+
+// Retrain starts training a new version of the model. Use WaitUntilTrained
+// to wait for it to finish.
+func (m *Model) Retrain(opts *RetrainModelOptions) error {
+	return m.RetrainContext(context.Background(), opts)
+}
+
+// RetrainContext is like Retrain with a context.
+func (m *Model) RetrainContext(ctx context.Context, opts *RetrainModelOptions) (err error) {
+	ctx, span := m.client.api._startSpan(ctx, "Model.Retrain", AttrModel, m.Name)
+	defer func() { _endSpan(span, err) }()
+
+	statement := "RETRAIN " + _quoteIdentifier(m.Project) + "." + _quoteIdentifier(m.Name)
+	if opts != nil {
+		statement += _fromClause(opts.Datasource, opts.Select)
+		statement += _usingClause(opts.Using)
+	}
+
+	if _, err := m.client.QueryDatabase(ctx, m.Project, statement); err != nil {
+		return fmt.Errorf("error retraining model: %w", err)
+	}
+	return m.RefreshContext(ctx)
+}
+
+// Predict runs the model on a batch of rows, returning one row of
+// predictions per input row.
+func (m *Model) Predict(rows []map[string]interface{}) ([]map[string]interface{}, error) {
+	return m.PredictContext(context.Background(), rows)
+}
+
+// PredictContext is like Predict with a context.
+func (m *Model) PredictContext(ctx context.Context, rows []map[string]interface{}) (_ []map[string]interface{}, err error) {
+	ctx, span := m.client.api._startSpan(ctx, "Model.Predict", AttrModel, m.Name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := m.client.api.post(
+		ctx,
+		fmt.Sprintf("/projects/%s/models/%s/predict", m.Project, m.Name),
+		map[string]interface{}{"data": rows},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error predicting: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var predictions []map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&predictions); err != nil {
+		return nil, fmt.Errorf("error decoding predictions: %w", err)
+	}
+	return predictions, nil
+}
+
+// Models manages the ML models of a project.
+type Models struct {
+	api     *RestAPI
+	client  *Client
+	project string
+}
+
+// NewModels creates a new Models instance scoped to project.
+func NewModels(client *Client, project string) *Models {
+	return &Models{
+		api:     client.api,
+		client:  client,
+		project: project,
+	}
+}
+
+// Create creates a model and starts training it. Training runs on the server;
+// use WaitUntilTrained on the returned model to wait for it.
+func (ms *Models) Create(name string, opts *CreateModelOptions, replace bool) (*Model, error) {
+	return ms.CreateContext(context.Background(), name, opts, replace)
+}
+
+// CreateContext is like Create with a context.
+func (ms *Models) CreateContext(ctx context.Context, name string, opts *CreateModelOptions, replace bool) (_ *Model, err error) {
+	ctx, span := ms.api._startSpan(ctx, "Models.Create", AttrModel, name, AttrProject, ms.project)
+	defer func() { _endSpan(span, err) }()
+
+	if opts == nil || opts.Predict == "" || opts.Select == "" {
+		return nil, &InvalidConfig{Message: fmt.Sprintf("model %s: Predict and Select are required", name)}
+	}
+
+	if replace {
+		_, err := ms._getInProject(ctx, ms.project, name)
+		if err == nil {
+			err = ms.DropContext(ctx, name)
+			if err != nil {
+				return nil, fmt.Errorf("error replacing model: %w", err)
+			}
+		} else if !errors.As(err, new(*ObjectNotFound)) {
+			return nil, fmt.Errorf("error checking for existing model: %w", err)
+		}
+	}
+
+	statement := "CREATE MODEL " + _quoteIdentifier(ms.project) + "." + _quoteIdentifier(name)
+	statement += _fromClause(opts.Datasource, opts.Select)
+	statement += " PREDICT " + _quoteIdentifier(opts.Predict)
+	using := make(map[string]interface{}, len(opts.Using)+1)
+	for k, v := range opts.Using {
+		using[k] = v
+	}
+	if opts.Engine != "" {
+		using["engine"] = opts.Engine
+	}
+	statement += _usingClause(using)
+
+	resp, err := ms.api.post(ctx, fmt.Sprintf("/projects/%s/models", ms.project), map[string]string{"query": statement})
+	if err != nil {
+		return nil, fmt.Errorf("error creating model: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return ms._getInProject(ctx, ms.project, name)
+}
+
+// List returns all models in the project.
+func (ms *Models) List() ([]*Model, error) {
+	return ms.ListContext(context.Background())
+}
+
+// ListContext is like List with a context.
+func (ms *Models) ListContext(ctx context.Context) (_ []*Model, err error) {
+	ctx, span := ms.api._startSpan(ctx, "Models.List", AttrProject, ms.project)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := ms.api.get(ctx, fmt.Sprintf("/projects/%s/models", ms.project))
+	if err != nil {
+		return nil, fmt.Errorf("error listing models: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var data []*Model
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("error decoding models response: %w", err)
+	}
+	for _, model := range data {
+		model.client = ms.client
+		model.Project = ms.project
+	}
+	return data, nil
+}
+
+// Get retrieves a model by name.
+func (ms *Models) Get(name string) (*Model, error) {
+	return ms.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get with a context.
+func (ms *Models) GetContext(ctx context.Context, name string) (*Model, error) {
+	return ms._getInProject(ctx, ms.project, name)
+}
+
+func (ms *Models) _getInProject(ctx context.Context, project string, name string) (_ *Model, err error) {
+	ctx, span := ms.api._startSpan(ctx, "Models.Get", AttrModel, name, AttrProject, project)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := ms.api.get(ctx, fmt.Sprintf("/projects/%s/models/%s", project, name))
+	if err != nil {
+		return nil, fmt.Errorf("error getting model: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var model Model
+	if err := json.NewDecoder(resp.Body).Decode(&model); err != nil {
+		return nil, fmt.Errorf("error decoding model response: %w", err)
+	}
+	model.client = ms.client
+	model.Project = project
+	return &model, nil
+}
+
+// Drop deletes a model by name.
+func (ms *Models) Drop(name string) error {
+	return ms.DropContext(context.Background(), name)
+}
+
+// DropContext is like Drop with a context.
+func (ms *Models) DropContext(ctx context.Context, name string) (err error) {
+	ctx, span := ms.api._startSpan(ctx, "Models.Drop", AttrProject, ms.project, AttrModel, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := ms.api.delete(ctx, fmt.Sprintf("/projects/%s/models/%s", ms.project, name))
+	if err != nil {
+		return fmt.Errorf("error deleting model: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func _fromClause(datasource string, query string) string {
+	if query == "" {
+		return ""
+	}
+	if datasource == "" {
+		return " FROM (" + query + ")"
+	}
+	return " FROM " + _quoteIdentifier(datasource) + " (" + query + ")"
+}
+
+func _usingClause(using map[string]interface{}) string {
+	if len(using) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(using))
+	for k := range using {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, 0, len(keys))
+	for _, k := range keys {
+		params = append(params, k+" = "+sqlvalue.Literal(using[k]))
+	}
+	return " USING " + strings.Join(params, ", ")
+}
+
+func _quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
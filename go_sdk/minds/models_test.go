@@ -0,0 +1,81 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestModelsCreateStatement(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			query = body["query"]
+		}
+		w.Write([]byte(`{"name": "rentals", "status": "generating"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", srv.URL)
+	opts := &CreateModelOptions{
+		Predict:    "rental_price",
+		Datasource: "example_db",
+		Select:     "SELECT * FROM home_rentals",
+		Engine:     "lightwood",
+		Using:      map[string]interface{}{"window": 10},
+	}
+	model, err := NewModels(client, "sales").Create("rentals", opts, false)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	want := "CREATE MODEL `sales`.`rentals` FROM `example_db` (SELECT * FROM home_rentals) PREDICT `rental_price` USING engine = 'lightwood', window = 10"
+	if query != want {
+		t.Errorf("query = %s, want %s", query, want)
+	}
+	if model.Project != "sales" {
+		t.Errorf("Project = %q, want %q", model.Project, "sales")
+	}
+
+	var invalid *InvalidConfig
+	if _, err := client.Models.Create("m", &CreateModelOptions{Predict: "y"}, false); !errors.As(err, &invalid) {
+		t.Errorf("Create() without Select error = %v, want InvalidConfig", err)
+	}
+}
+
+func TestModelWaitUntilTrained(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []string
+		wantErr  bool
+	}{
+		{"completes", []string{ModelStatusTraining, ModelStatusComplete}, false},
+		{"fails", []string{ModelStatusTraining, ModelStatusError}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[polls]
+				if polls < len(tt.statuses)-1 {
+					polls++
+				}
+				json.NewEncoder(w).Encode(map[string]string{"name": "m", "status": status, "error": "bad data"})
+			}))
+			defer srv.Close()
+
+			client := NewClient("key", srv.URL)
+			model := &Model{client: client, Project: "mindsdb", Name: "m", Status: ModelStatusGenerating}
+			err := model.WaitUntilTrained(context.Background(), time.Millisecond)
+			var failed *TrainingFailed
+			if tt.wantErr != errors.As(err, &failed) {
+				t.Errorf("WaitUntilTrained() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
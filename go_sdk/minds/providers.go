@@ -0,0 +1,103 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// LLMModel describes a language model offered by a provider.
+type LLMModel struct {
+	Name          string `json:"name"`
+	Streaming     bool   `json:"streaming"`
+	ToolCalling   bool   `json:"tool_calling"`
+	ContextWindow int    `json:"context_window"`
+}
+
+// Provider describes an LLM provider and the models it offers.
+type Provider struct {
+	Name   string      `json:"name"`
+	Models []*LLMModel `json:"models"`
+}
+
+// Model returns the provider's model with the given name, or nil.
+func (p *Provider) Model(name string) *LLMModel {
+	for _, model := range p.Models {
+		if model.Name == name {
+			return model
+		}
+	}
+	return nil
+}
+
+// Providers returns the LLM providers and models supported by the server.
+func (c *Client) Providers() ([]*Provider, error) {
+	return c.ProvidersContext(context.Background())
+}
+
+// ProvidersContext is like Providers with a context.
+func (c *Client) ProvidersContext(ctx context.Context) (_ []*Provider, err error) {
+	ctx, span := c.api._startSpan(ctx, "Client.Providers")
+	defer func() { _endSpan(span, err) }()
+
+	providers, err := _listProviders(ctx, c.api)
+	if err != nil {
+		return nil, err
+	}
+	c.providers.set(providers)
+	return providers, nil
+}
+
+func _listProviders(ctx context.Context, api *RestAPI) ([]*Provider, error) {
+	resp, err := api.get(ctx, "/llm/providers")
+	if err != nil {
+		return nil, fmt.Errorf("error listing providers: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var providers []*Provider
+	if err := json.NewDecoder(resp.Body).Decode(&providers); err != nil {
+		return nil, fmt.Errorf("error decoding providers response: %w", err)
+	}
+	return providers, nil
+}
+
+// providerCatalog caches the server's providers for client-side validation.
+type providerCatalog struct {
+	catalog[[]*Provider]
+}
+
+func newProviderCatalog(api *RestAPI) *providerCatalog {
+	return &providerCatalog{catalog[[]*Provider]{
+		api:   api,
+		name:  "providers",
+		fetch: _listProviders,
+	}}
+}
+
+// validate checks that provider offers model. Either may be empty: an empty
+// provider accepts a model offered by any provider.
+func (pc *providerCatalog) validate(ctx context.Context, provider string, model string) error {
+	if provider == "" && model == "" {
+		return nil
+	}
+	providers, ok := pc.get(ctx)
+	if !ok {
+		return nil
+	}
+	for _, p := range providers {
+		if provider != "" && p.Name != provider {
+			continue
+		}
+		if model == "" || p.Model(model) != nil {
+			return nil
+		}
+		if provider != "" {
+			return &InvalidConfig{Message: fmt.Sprintf("provider %q does not offer model %q", provider, model)}
+		}
+	}
+	if provider != "" {
+		return &InvalidConfig{Message: fmt.Sprintf("unknown provider %q", provider)}
+	}
+	return &InvalidConfig{Message: fmt.Sprintf("unknown model %q", model)}
+}
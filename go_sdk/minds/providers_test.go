@@ -0,0 +1,60 @@
+package minds
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProviderCatalogValidate(t *testing.T) {
+	pc := newProviderCatalog(NewRestAPI("key", "http://localhost"))
+	pc.set([]*Provider{
+		{Name: "openai", Models: []*LLMModel{{Name: "gpt-4o"}}},
+		{Name: "anthropic", Models: []*LLMModel{{Name: "claude"}}},
+	})
+
+	tests := []struct {
+		provider, model string
+		wantErr         bool
+	}{
+		{"", "", false},
+		{"openai", "gpt-4o", false},
+		{"openai", "", false},
+		{"", "claude", false},
+		{"openai", "claude", true},
+		{"mistral", "", true},
+		{"", "unknown", true},
+	}
+	for _, tt := range tests {
+		err := pc.validate(context.Background(), tt.provider, tt.model)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validate(%q, %q) error = %v, wantErr %v", tt.provider, tt.model, err, tt.wantErr)
+		}
+		if err != nil && !errors.As(err, new(*InvalidConfig)) {
+			t.Errorf("validate(%q, %q) error = %T, want *InvalidConfig", tt.provider, tt.model, err)
+		}
+	}
+}
+
+func TestMindCreateSkipsValidationWhenCatalogFails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/llm/providers":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.Write([]byte(`{"name": "m", "model_name": "gpt-4o", "provider": "openai"}`))
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", srv.URL)
+	modelName, provider := "gpt-4o", "openai"
+	opts := &CreateMindOptions{ModelName: &modelName, Provider: &provider}
+	if _, err := client.Minds.Create("m", opts, false); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+}
@@ -0,0 +1,278 @@
+package minds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the rate of REST calls. It can be
+// shared by several clients using the same account.
+type RateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu          sync.Mutex
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+// defaultMaxRetryAfter is the longest Retry-After period waited out before
+// retrying a rate limited request, unless set with WithMaxRetryAfter.
+const defaultMaxRetryAfter = time.Minute
+
+// NewRateLimiter creates a RateLimiter allowing requestsPerSecond calls on
+// average and bursts of up to burst calls. requestsPerSecond must be
+// positive.
+func NewRateLimiter(requestsPerSecond float64, burst int) (*RateLimiter, error) {
+	if requestsPerSecond <= 0 {
+		return nil, &InvalidConfig{Message: fmt.Sprintf("rate limit must be positive, got %v requests per second", requestsPerSecond)}
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}, nil
+}
+
+// WithRateLimiter limits the rate of the client's REST calls.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(r *RestAPI) {
+		r.RateLimiter = limiter
+	}
+}
+
+// WithMaxRetryAfter sets the longest Retry-After period the client waits
+// out before retrying a rate limited request. Longer periods fail with
+// RateLimited, whose RetryAfter tells the caller when to try again.
+func WithMaxRetryAfter(d time.Duration) Option {
+	return func(r *RestAPI) {
+		r.MaxRetryAfter = d
+	}
+}
+
+// Wait blocks until a call is allowed or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay := l._reserve()
+		if delay == 0 {
+			return nil
+		}
+		if err := _sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// PauseUntil blocks all calls until t, for example when the service asks
+// clients to back off.
+func (l *RateLimiter) PauseUntil(t time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if t.After(l.pausedUntil) {
+		l.pausedUntil = t
+	}
+}
+
+// _reserve takes a token and returns zero, or returns how long to wait
+// before trying again.
+func (l *RateLimiter) _reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// CompletionLimiter limits the number of completions running at once, in
+// total and per mind or agent. Zero means no limit.
+type CompletionLimiter struct {
+	maxConcurrent int
+	maxPerMind    int
+
+	global chan struct{}
+	mu     sync.Mutex
+	minds  map[string]chan struct{}
+}
+
+// NewCompletionLimiter creates a CompletionLimiter allowing maxConcurrent
+// completions in total and maxPerMind for any single mind.
+func NewCompletionLimiter(maxConcurrent int, maxPerMind int) *CompletionLimiter {
+	l := &CompletionLimiter{
+		maxConcurrent: maxConcurrent,
+		maxPerMind:    maxPerMind,
+		minds:         make(map[string]chan struct{}),
+	}
+	if maxConcurrent > 0 {
+		l.global = make(chan struct{}, maxConcurrent)
+	}
+	return l
+}
+
+// WithCompletionLimiter limits the number of the client's concurrent completions.
+func WithCompletionLimiter(limiter *CompletionLimiter) Option {
+	return func(r *RestAPI) {
+		r.CompletionLimiter = limiter
+	}
+}
+
+// Acquire blocks until a completion for mind may start or ctx is done.
+// Each successful Acquire must be followed by Release.
+func (l *CompletionLimiter) Acquire(ctx context.Context, mind string) error {
+	perMind := l._mindSlots(mind)
+	if perMind != nil {
+		select {
+		case perMind <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if l.global != nil {
+		select {
+		case l.global <- struct{}{}:
+		case <-ctx.Done():
+			if perMind != nil {
+				<-perMind
+			}
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Release ends a completion for mind started with Acquire.
+func (l *CompletionLimiter) Release(mind string) {
+	if l.global != nil {
+		<-l.global
+	}
+	if perMind := l._mindSlots(mind); perMind != nil {
+		<-perMind
+	}
+}
+
+func (l *CompletionLimiter) _mindSlots(mind string) chan struct{} {
+	if l.maxPerMind <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	slots, ok := l.minds[mind]
+	if !ok {
+		slots = make(chan struct{}, l.maxPerMind)
+		l.minds[mind] = slots
+	}
+	return slots
+}
+
+// rateLimitTransport honours 429 responses: it pauses the client's rate
+// limiter for the Retry-After period and retries the request once when the
+// limiter lets it through. Periods longer than the client's MaxRetryAfter
+// are returned to the caller as RateLimited.
+type rateLimitTransport struct {
+	api  *RestAPI
+	base http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+
+	delay, ok := _retryAfter(resp.Header.Get("Retry-After"))
+	if !ok || !_canReplay(req) {
+		return resp, nil
+	}
+	if delay > t.api._maxRetryAfter() {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.api._log(LevelWarn, "rate limited beyond the retry limit", "method", req.Method, "path", _apiPath(req.URL.Path), "retry_after", delay)
+		return nil, &RateLimited{Message: string(body), RetryAfter: delay}
+	}
+	resp.Body.Close()
+	_countRetry(req.Context())
+	t.api._log(LevelWarn, "rate limited, retrying", "method", req.Method, "path", _apiPath(req.URL.Path), "retry_after", delay)
+
+	if t.api.RateLimiter != nil {
+		// Other requests sharing the limiter back off too.
+		t.api.RateLimiter.PauseUntil(time.Now().Add(delay))
+		err = t.api.RateLimiter.Wait(req.Context())
+	} else {
+		err = _sleep(req.Context(), delay)
+	}
+	if err != nil {
+		return nil, err
+	}
+	retry, err := _replay(req)
+	if err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(retry)
+}
+
+func (r *RestAPI) _maxRetryAfter() time.Duration {
+	if r.MaxRetryAfter <= 0 {
+		return defaultMaxRetryAfter
+	}
+	return r.MaxRetryAfter
+}
+
+// _isRateLimited reports whether err is a RateLimited error.
+func _isRateLimited(err error) bool {
+	var limited *RateLimited
+	return errors.As(err, &limited)
+}
+
+// _retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func _retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		delay := time.Until(at)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// _sleep waits for d or until ctx is done.
+func _sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("error waiting for rate limit: %w", ctx.Err())
+	}
+}
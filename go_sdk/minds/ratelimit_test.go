@@ -0,0 +1,143 @@
+package minds
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	tests := []struct {
+		rate    float64
+		burst   int
+		wantErr bool
+	}{
+		{10, 5, false},
+		{0.5, 0, false},
+		{0, 1, true},
+		{-1, 1, true},
+	}
+	for _, tt := range tests {
+		_, err := NewRateLimiter(tt.rate, tt.burst)
+		var invalid *InvalidConfig
+		if tt.wantErr != errors.As(err, &invalid) {
+			t.Errorf("NewRateLimiter(%v, %d) error = %v, wantErr %v", tt.rate, tt.burst, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter, _ := NewRateLimiter(1, 2)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("Wait() within burst error = %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() beyond burst error = %v, want deadline exceeded", err)
+	}
+}
+
+func TestListContextCancelsRateLimitWait(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	limiter, _ := NewRateLimiter(0.001, 1)
+	limiter.PauseUntil(time.Now().Add(time.Hour))
+	client := NewClient("key", srv.URL, WithRateLimiter(limiter))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Skills.ListContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ListContext() error = %v, want deadline exceeded", err)
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	tests := []struct {
+		name          string
+		retryAfter    string
+		wantCalls     int32
+		wantErr       bool
+		wantRetryWait time.Duration
+	}{
+		{"retries after short wait", "0", 2, false, 0},
+		{"no Retry-After", "", 1, true, 0},
+		{"Retry-After beyond limit", "120", 1, true, 120 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				w.Write([]byte(`[]`))
+			}))
+			defer srv.Close()
+
+			limiter, _ := NewRateLimiter(100, 1)
+			client := NewClient("key", srv.URL, WithRateLimiter(limiter), WithMaxRetryAfter(time.Second))
+			_, err := client.Skills.List()
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			var limited *RateLimited
+			if tt.wantErr != errors.As(err, &limited) {
+				t.Fatalf("List() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && limited.RetryAfter != tt.wantRetryWait {
+				t.Errorf("RetryAfter = %v, want %v", limited.RetryAfter, tt.wantRetryWait)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{"Mon, 02 Jan 2006 15:04:05 GMT", 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := _retryAfter(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("_retryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestCompletionLimiterAcquireCancels(t *testing.T) {
+	limiter := NewCompletionLimiter(0, 1)
+	if err := limiter.Acquire(context.Background(), "m"); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Acquire(ctx, "m"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire() on a full mind error = %v, want deadline exceeded", err)
+	}
+	if err := limiter.Acquire(context.Background(), "other"); err != nil {
+		t.Errorf("Acquire() for another mind error = %v", err)
+	}
+}
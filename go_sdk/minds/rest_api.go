@@ -2,10 +2,13 @@ package minds
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // RestAPI provides methods for interacting with the MindsDB REST API.
@@ -13,10 +16,70 @@ type RestAPI struct {
 	APIKey  string
 	BaseURL string
 	Client  *http.Client
+	// Auth authenticates requests. When nil, APIKey is sent as a bearer token.
+	Auth Authenticator
+	// Middleware wraps the transport of every request; see WithMiddleware.
+	Middleware []Middleware
+	// Tracer receives spans for SDK operations and HTTP requests.
+	Tracer Tracer
+	// Logger receives log records; see WithLogger.
+	Logger Logger
+	// DebugDump logs redacted request and response bodies; see WithDebugDump.
+	DebugDump bool
+	// Metrics receives request, operation and completion measurements.
+	Metrics Metrics
+	// Usage records completion token usage and enforces its budget.
+	Usage *UsageAccountant
+	// RateLimiter limits the rate of REST calls.
+	RateLimiter *RateLimiter
+	// MaxRetryAfter is the longest Retry-After period waited out before
+	// retrying; see WithMaxRetryAfter.
+	MaxRetryAfter time.Duration
+	// CompletionLimiter limits the number of concurrent completions.
+	CompletionLimiter *CompletionLimiter
+	// CircuitBreaker fails requests fast while the service is failing.
+	CircuitBreaker *CircuitBreaker
+	// Endpoints fails requests over between deployments; see WithEndpoints.
+	Endpoints *EndpointPool
+}
+
+// Option configures a RestAPI.
+type Option func(*RestAPI)
+
+// WithAuthenticator sets how requests are authenticated.
+func WithAuthenticator(auth Authenticator) Option {
+	return func(r *RestAPI) {
+		r.Auth = auth
+	}
 }
 
 // NewRestAPI creates a new RestAPI instance.
-func NewRestAPI(apiKey string, baseURL string) *RestAPI {
+func NewRestAPI(apiKey string, baseURL string, opts ...Option) *RestAPI {
+	r := &RestAPI{
+		APIKey:  apiKey,
+		BaseURL: _normalizeBaseURL(baseURL),
+		Client:  &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if session, ok := r.Auth.(*SessionAuth); ok {
+		if session.Client.Transport == nil {
+			// Logins go to the same servers, so they need the same network settings.
+			session.Client.Transport = r.Client.Transport
+		}
+		if r.Endpoints != nil {
+			for _, endpoint := range r.Endpoints.endpoints {
+				session._addDeployment(endpoint.baseURL, endpoint.llmURL)
+			}
+		}
+	}
+	return r
+}
+
+// _normalizeBaseURL applies the default server and makes sure the URL ends
+// with the /api/ prefix.
+func _normalizeBaseURL(baseURL string) string {
 	if baseURL == "" {
 		baseURL = "https://mdb.ai"
 	}
@@ -26,90 +89,146 @@ func NewRestAPI(apiKey string, baseURL string) *RestAPI {
 	if baseURL[len(baseURL)-4:] != "/api/" {
 		baseURL = baseURL + "api/"
 	}
-
-	return &RestAPI{
-		APIKey:  apiKey,
-		BaseURL: baseURL,
-		Client:  &http.Client{},
-	}
+	return baseURL
 }
 
 func (r *RestAPI) _headers() http.Header {
-	headers := http.Header{}
-	headers.Set("Authorization", "Bearer "+r.APIKey)
-	return headers
+	return http.Header{}
 }
 
-func (r *RestAPI) get(url string) (*http.Response, error) {
-	req, err := http.NewRequest("GET", r.BaseURL+url, nil)
-	if err != nil {
-		return nil, err
+func (r *RestAPI) _authenticator() Authenticator {
+	if r.Auth != nil {
+		return r.Auth
 	}
-	req.Header = r._headers()
-	resp, err := r.Client.Do(req)
-	if err != nil {
-		return nil, err
+	return &BearerAuth{APIKey: r.APIKey}
+}
+
+// _httpClient returns r.Client with middleware, tracing, metrics, endpoint
+// failover, circuit breaking, rate limit handling, authentication and logging
+// added to its transport. It is used for both REST and completion traffic.
+func (r *RestAPI) _httpClient() *http.Client {
+	base := r.Client.Transport
+	if base == nil {
+		base = http.DefaultTransport
 	}
-	defer resp.Body.Close()
-	_raiseForStatus(resp)
-	return resp, nil
+	var transport http.RoundTripper = &loggingTransport{api: r, base: base}
+	transport = &authTransport{api: r, auth: r._authenticator(), base: transport}
+	transport = &rateLimitTransport{api: r, base: transport}
+	if r.CircuitBreaker != nil {
+		transport = &circuitBreakerTransport{api: r, breaker: r.CircuitBreaker, base: transport}
+	}
+	if r.Endpoints != nil {
+		transport = &failoverTransport{api: r, pool: r.Endpoints, base: transport}
+	}
+	if r.Metrics != nil {
+		transport = &metricsTransport{api: r, base: transport}
+	}
+	transport = &tracingTransport{api: r, base: transport}
+
+	client := *r.Client
+	client.Transport = _chain(r.Middleware, transport)
+	return &client
 }
 
-func (r *RestAPI) delete(url string) (*http.Response, error) {
-	req, err := http.NewRequest("DELETE", r.BaseURL+url, nil)
+func (r *RestAPI) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", r._url(url), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header = r._headers()
-	resp, err := r.Client.Do(req)
+	return r._send(req)
+}
+
+func (r *RestAPI) delete(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", r._url(url), nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	_raiseForStatus(resp)
-	return resp, nil
+	req.Header = r._headers()
+	return r._send(req)
+}
+
+func (r *RestAPI) post(ctx context.Context, url string, data interface{}) (*http.Response, error) {
+	return r._sendJSON(ctx, "POST", url, data)
+}
+
+func (r *RestAPI) patch(ctx context.Context, url string, data interface{}) (*http.Response, error) {
+	return r._sendJSON(ctx, "PATCH", url, data)
+}
+
+func (r *RestAPI) put(ctx context.Context, url string, data interface{}) (*http.Response, error) {
+	return r._sendJSON(ctx, "PUT", url, data)
 }
 
-func (r *RestAPI) post(url string, data interface{}) (*http.Response, error) {
+func (r *RestAPI) _sendJSON(ctx context.Context, method string, url string, data interface{}) (*http.Response, error) {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", r.BaseURL+url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, method, r._url(url), bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, err
 	}
 	req.Header = r._headers()
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := r.Client.Do(req)
+	return r._send(req)
+}
+
+// upload sends a raw request body, such as a multipart form, with PUT.
+func (r *RestAPI) upload(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "PUT", r._url(url), body)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	_raiseForStatus(resp)
-	return resp, nil
+	req.Header = r._headers()
+	req.Header.Set("Content-Type", contentType)
+	return r._send(req)
 }
 
-func (r *RestAPI) patch(url string, data interface{}) (*http.Response, error) {
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return nil, err
+// _url joins an API path onto the base URL, which always ends with a slash.
+func (r *RestAPI) _url(path string) string {
+	return r.BaseURL + strings.TrimPrefix(path, "/")
+}
+
+// _send performs the request and maps error status codes to typed errors.
+// On success the caller is responsible for closing the response body.
+func (r *RestAPI) _send(req *http.Request) (*http.Response, error) {
+	if r.RateLimiter != nil {
+		if err := r.RateLimiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
 	}
-	req, err := http.NewRequest("PATCH", r.BaseURL+url, bytes.NewBuffer(jsonData))
+	resp, err := r._httpClient().Do(req)
 	if err != nil {
 		return nil, err
 	}
-	req.Header = r._headers()
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := r.Client.Do(req)
-	if err != nil {
+	if err := _raiseForStatus(resp); err != nil {
+		resp.Body.Close()
+		r._log(LevelDebug, "mapped error status", "method", req.Method, "path", _apiPath(req.URL.Path), "status", resp.StatusCode, "error", fmt.Sprintf("%T", err))
 		return nil, err
 	}
-	defer resp.Body.Close()
-	_raiseForStatus(resp)
 	return resp, nil
 }
 
+// _canReplay reports whether req can be sent again: its body must be empty
+// or recreatable with GetBody.
+func _canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// _replay returns a copy of req with a fresh body, to send it again.
+func _replay(req *http.Request) (*http.Request, error) {
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return retry, nil
+}
+
 func _raiseForStatus(response *http.Response) error {
 	if response.StatusCode == http.StatusNotFound {
 		body, _ := io.ReadAll(response.Body)
@@ -126,6 +245,12 @@ func _raiseForStatus(response *http.Response) error {
 		return &Unauthorized{Message: string(body)}
 	}
 
+	if response.StatusCode == http.StatusTooManyRequests {
+		body, _ := io.ReadAll(response.Body)
+		retryAfter, _ := _retryAfter(response.Header.Get("Retry-After"))
+		return &RateLimited{Message: string(body), RetryAfter: retryAfter}
+	}
+
 	if response.StatusCode >= 400 && response.StatusCode < 600 {
 		body, _ := io.ReadAll(response.Body)
 		return &UnknownError{Message: fmt.Sprintf("%s: %s", response.Status, string(body))}
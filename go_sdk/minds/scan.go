@@ -0,0 +1,283 @@
+package minds
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_sdk/internal/sqlvalue"
+)
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+// Rows iterates over the rows of a query result.
+//
+//	rows := result.Rows()
+//	for rows.Next() {
+//		var user User
+//		if err := rows.Scan(&user); err != nil {
+//			return err
+//		}
+//	}
+type Rows struct {
+	result *QueryResult
+	pos    int
+}
+
+// Rows returns an iterator over the result's rows.
+func (qr *QueryResult) Rows() *Rows {
+	return &Rows{result: qr}
+}
+
+// Next advances to the next row, returning false when there are no more rows.
+func (r *Rows) Next() bool {
+	if r.pos >= len(r.result.Data) {
+		return false
+	}
+	r.pos++
+	return true
+}
+
+// Scan copies the current row into dest. A single pointer to a struct is
+// filled by column name, using the `mindsdb:"col"` field tag or else a
+// case-insensitive match on the field name; fields tagged "-" are skipped.
+// Otherwise dest holds one pointer per column, in column order.
+func (r *Rows) Scan(dest ...interface{}) error {
+	if r.pos == 0 || r.pos > len(r.result.Data) {
+		return fmt.Errorf("scan called without a current row")
+	}
+	row := r.result.Data[r.pos-1]
+
+	if len(dest) == 1 {
+		v := reflect.ValueOf(dest[0])
+		if v.Kind() == reflect.Pointer && !v.IsNil() && _isStructTarget(v.Elem()) {
+			return r._scanStruct(row, v.Elem())
+		}
+	}
+
+	if len(dest) != len(r.result.Columns) {
+		return fmt.Errorf("expected %d destination arguments in scan, got %d", len(r.result.Columns), len(dest))
+	}
+	for i, d := range dest {
+		v := reflect.ValueOf(d)
+		if v.Kind() != reflect.Pointer || v.IsNil() {
+			return fmt.Errorf("destination %d is not a non-nil pointer", i)
+		}
+		var value interface{}
+		if i < len(row) {
+			value = row[i]
+		}
+		if err := _assign(v.Elem(), value); err != nil {
+			return fmt.Errorf("column %s: %w", r.result.Columns[i].Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rows) _scanStruct(row []interface{}, target reflect.Value) error {
+	fields := _structFields(target.Type())
+	for i, column := range r.result.Columns {
+		index, ok := fields[strings.ToLower(column.Name)]
+		if !ok {
+			continue
+		}
+		var value interface{}
+		if i < len(row) {
+			value = row[i]
+		}
+		if err := _assign(_fieldByIndexAlloc(target, index), value); err != nil {
+			return fmt.Errorf("column %s: %w", column.Name, err)
+		}
+	}
+	return nil
+}
+
+// QueryInto runs sql and scans every row into a T, which must be a struct.
+func QueryInto[T any](ctx context.Context, client *Client, sql string) ([]T, error) {
+	var zero T
+	if !_isStructTarget(reflect.ValueOf(&zero).Elem()) {
+		return nil, fmt.Errorf("QueryInto requires a struct type, got %T", zero)
+	}
+
+	result, err := client.Query(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]T, 0, len(result.Data))
+	rows := result.Rows()
+	for rows.Next() {
+		var item T
+		if err := rows.Scan(&item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// _isStructTarget reports whether v is a struct that is scanned field by field.
+func _isStructTarget(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct || v.Type() == timeType {
+		return false
+	}
+	return !reflect.PointerTo(v.Type()).Implements(scannerType)
+}
+
+// _structFields maps lower-cased column names to field indexes.
+func _structFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup("mindsdb")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && !hasTag {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				// A nil unexported pointer cannot be allocated through
+				// reflection, so its fields are not scanned.
+				if !f.IsExported() {
+					continue
+				}
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for name, index := range _structFields(ft) {
+					if _, ok := fields[name]; !ok {
+						fields[name] = append([]int{i}, index...)
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag != "" {
+			name = tag
+		}
+		fields[strings.ToLower(name)] = []int{i}
+	}
+	return fields
+}
+
+// _fieldByIndexAlloc returns the nested field at index, allocating nil
+// embedded struct pointers on the way.
+func _fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// _assign converts a decoded JSON value into dst.
+func _assign(dst reflect.Value, value interface{}) error {
+	if dst.CanAddr() && dst.Addr().Type().Implements(scannerType) {
+		return dst.Addr().Interface().(sql.Scanner).Scan(sqlvalue.DriverValue(value))
+	}
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer {
+		elem := reflect.New(dst.Type().Elem())
+		if err := _assign(elem.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+	if dst.Type() == timeType {
+		t, err := sqlvalue.ParseTime(value)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Interface:
+		dst.Set(reflect.ValueOf(value))
+	case reflect.String:
+		switch v := value.(type) {
+		case string:
+			dst.SetString(v)
+		case json.Number:
+			dst.SetString(v.String())
+		case bool:
+			dst.SetString(strconv.FormatBool(v))
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			dst.SetString(string(data))
+		}
+	case reflect.Bool:
+		b, err := sqlvalue.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := sqlvalue.ParseInt(value)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowInt(n) {
+			return fmt.Errorf("value %d overflows %s", n, dst.Type())
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := sqlvalue.ParseInt(value)
+		if err != nil {
+			return err
+		}
+		if n < 0 || dst.OverflowUint(uint64(n)) {
+			return fmt.Errorf("value %d overflows %s", n, dst.Type())
+		}
+		dst.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		f, err := sqlvalue.ParseFloat(value)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowFloat(f) {
+			return fmt.Errorf("value %v overflows %s", f, dst.Type())
+		}
+		dst.SetFloat(f)
+	default:
+		// Slices, maps and structs are decoded from JSON, either nested in
+		// the response or serialized in a string column.
+		data, ok := value.(string)
+		raw := []byte(data)
+		if !ok {
+			var err error
+			if raw, err = json.Marshal(value); err != nil {
+				return err
+			}
+		}
+		if err := json.Unmarshal(raw, dst.Addr().Interface()); err != nil {
+			return fmt.Errorf("cannot convert %T to %s: %w", value, dst.Type(), err)
+		}
+	}
+	return nil
+}
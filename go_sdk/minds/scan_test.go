@@ -0,0 +1,102 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAssign(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{"int from number", json.Number("42"), int64(42), false},
+		{"int from integral float", json.Number("42.0"), int(42), false},
+		{"int from fraction", json.Number("4.5"), int(0), true},
+		{"int overflow", json.Number("300"), int8(0), true},
+		{"uint negative", json.Number("-1"), uint(0), true},
+		{"int from string", " 7 ", int(7), false},
+		{"float from number", json.Number("1.5"), float64(1.5), false},
+		{"bool from string", "true", true, false},
+		{"bool from number", json.Number("0"), false, false},
+		{"string from number", json.Number("12"), "12", false},
+		{"string from object", map[string]interface{}{"a": "b"}, `{"a":"b"}`, false},
+		{"time from string", "2024-01-02 03:04:05", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), false},
+		{"time from epoch", json.Number("1700000000"), time.Unix(1700000000, 0).UTC(), false},
+		{"time from garbage", "soon", time.Time{}, true},
+		{"slice from JSON string", `[1,2]`, []int{1, 2}, false},
+		{"nil zeroes", nil, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := reflect.New(reflect.TypeOf(tt.want)).Elem()
+			err := _assign(dst, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("_assign() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(dst.Interface(), tt.want) {
+				t.Errorf("_assign() = %#v, want %#v", dst.Interface(), tt.want)
+			}
+		})
+	}
+}
+
+type scanBase struct {
+	ID int
+}
+
+type scanHidden struct {
+	Secret string
+}
+
+type scanUser struct {
+	scanBase
+	*scanHidden
+	Name  string `mindsdb:"user_name"`
+	Email *string
+	Skip  string `mindsdb:"-"`
+}
+
+func TestRowsScanStruct(t *testing.T) {
+	result := &QueryResult{
+		Columns: []*QueryColumn{{Name: "id"}, {Name: "user_name"}, {Name: "EMAIL"}, {Name: "secret"}, {Name: "skip"}},
+		Data:    [][]interface{}{{json.Number("1"), "ann", "ann@example.com", "s3cret", "x"}},
+	}
+	rows := result.Rows()
+	if !rows.Next() {
+		t.Fatal("Next() = false")
+	}
+	var user scanUser
+	if err := rows.Scan(&user); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if user.ID != 1 || user.Name != "ann" || user.Email == nil || *user.Email != "ann@example.com" {
+		t.Errorf("Scan() = %+v", user)
+	}
+	if user.scanHidden != nil || user.Skip != "" {
+		t.Errorf("Scan() filled skipped fields: %+v", user)
+	}
+}
+
+func TestQueryIntoRejectsNonStructWithoutRows(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"type": "table", "column_names": ["a"], "column_types": ["int"], "data": []}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", srv.URL)
+	if _, err := QueryInto[int](context.Background(), client, "SELECT a FROM t"); err == nil {
+		t.Error("QueryInto[int]() returned no error")
+	}
+	items, err := QueryInto[scanUser](context.Background(), client, "SELECT a FROM t")
+	if err != nil || len(items) != 0 {
+		t.Errorf("QueryInto[scanUser]() = %v, %v; want no items", items, err)
+	}
+}
@@ -0,0 +1,236 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Skill types supported by MindsDB agents.
+const (
+	SkillTypeText2SQL  = "text2sql"
+	SkillTypeRetrieval = "retrieval"
+)
+
+// SkillConfig represents the configuration for an agent skill.
+type SkillConfig struct {
+	Name   string                 `json:"name"`
+	Type   string                 `json:"type"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// Text2SQLSkill returns the configuration for a skill that answers questions
+// by querying tables of a datasource.
+func Text2SQLSkill(name string, database string, tables []string, description string) *SkillConfig {
+	return &SkillConfig{
+		Name: name,
+		Type: SkillTypeText2SQL,
+		Params: map[string]interface{}{
+			"database":    database,
+			"tables":      tables,
+			"description": description,
+		},
+	}
+}
+
+// RetrievalSkill returns the configuration for a skill that answers questions
+// from documents in a knowledge base.
+func RetrievalSkill(name string, knowledgeBase string, description string) *SkillConfig {
+	return &SkillConfig{
+		Name: name,
+		Type: SkillTypeRetrieval,
+		Params: map[string]interface{}{
+			"source":      knowledgeBase,
+			"description": description,
+		},
+	}
+}
+
+// Skill represents a MindsDB agent skill.
+type Skill struct {
+	api     *RestAPI
+	Project string                 `json:"project"`
+	Name    string                 `json:"name"`
+	Type    string                 `json:"type"`
+	Params  map[string]interface{} `json:"params"`
+}
+
+// Update replaces the skill's parameters.
+func (s *Skill) Update(params map[string]interface{}) error {
+	return s.UpdateContext(context.Background(), params)
+}
+
+// UpdateContext is like Update with a context.
+func (s *Skill) UpdateContext(ctx context.Context, params map[string]interface{}) (err error) {
+	ctx, span := s.api._startSpan(ctx, "Skill.Update", AttrSkill, s.Name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := s.api.put(
+		ctx,
+		fmt.Sprintf("/projects/%s/skills/%s", s.Project, s.Name),
+		map[string]interface{}{"skill": map[string]interface{}{"type": s.Type, "params": params}},
+	)
+	if err != nil {
+		return fmt.Errorf("error updating skill: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	s.Params = params
+	return nil
+}
+
+// Skills manages the agent skills of a project.
+type Skills struct {
+	api     *RestAPI
+	project string
+}
+
+// NewSkills creates a new Skills instance scoped to project.
+func NewSkills(client *Client, project string) *Skills {
+	return &Skills{
+		api:     client.api,
+		project: project,
+	}
+}
+
+// Create creates a new skill.
+func (ss *Skills) Create(skillConfig *SkillConfig, replace bool) (*Skill, error) {
+	return ss.CreateContext(context.Background(), skillConfig, replace)
+}
+
+// CreateContext is like Create with a context.
+func (ss *Skills) CreateContext(ctx context.Context, skillConfig *SkillConfig, replace bool) (_ *Skill, err error) {
+	ctx, span := ss.api._startSpan(ctx, "Skills.Create", AttrSkill, skillConfig.Name)
+	defer func() { _endSpan(span, err) }()
+
+	if replace {
+		_, err := ss.GetContext(ctx, skillConfig.Name)
+		if err == nil {
+			err = ss.DropContext(ctx, skillConfig.Name)
+			if err != nil {
+				return nil, fmt.Errorf("error replacing skill: %w", err)
+			}
+		} else if !errors.As(err, new(*ObjectNotFound)) {
+			return nil, fmt.Errorf("error checking for existing skill: %w", err)
+		}
+	}
+
+	resp, err := ss.api.post(
+		ctx,
+		fmt.Sprintf("/projects/%s/skills", ss.project),
+		map[string]interface{}{"skill": skillConfig},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating skill: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return ss.GetContext(ctx, skillConfig.Name)
+}
+
+// List returns all skills in the project.
+func (ss *Skills) List() ([]*Skill, error) {
+	return ss.ListContext(context.Background())
+}
+
+// ListContext is like List with a context.
+func (ss *Skills) ListContext(ctx context.Context) (_ []*Skill, err error) {
+	ctx, span := ss.api._startSpan(ctx, "Skills.List")
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := ss.api.get(ctx, fmt.Sprintf("/projects/%s/skills", ss.project))
+	if err != nil {
+		return nil, fmt.Errorf("error listing skills: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var data []*Skill
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("error decoding skills response: %w", err)
+	}
+	for _, skill := range data {
+		skill.api = ss.api
+		skill.Project = ss.project
+	}
+	return data, nil
+}
+
+// Get retrieves a skill by name.
+func (ss *Skills) Get(name string) (*Skill, error) {
+	return ss.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get with a context.
+func (ss *Skills) GetContext(ctx context.Context, name string) (_ *Skill, err error) {
+	ctx, span := ss.api._startSpan(ctx, "Skills.Get", AttrSkill, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := ss.api.get(ctx, fmt.Sprintf("/projects/%s/skills/%s", ss.project, name))
+	if err != nil {
+		return nil, fmt.Errorf("error getting skill: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var skill Skill
+	if err := json.NewDecoder(resp.Body).Decode(&skill); err != nil {
+		return nil, fmt.Errorf("error decoding skill response: %w", err)
+	}
+	skill.api = ss.api
+	skill.Project = ss.project
+	return &skill, nil
+}
+
+// Drop deletes a skill by name.
+func (ss *Skills) Drop(name string) error {
+	return ss.DropContext(context.Background(), name)
+}
+
+// DropContext is like Drop with a context.
+func (ss *Skills) DropContext(ctx context.Context, name string) (err error) {
+	ctx, span := ss.api._startSpan(ctx, "Skills.Drop", AttrSkill, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := ss.api.delete(ctx, fmt.Sprintf("/projects/%s/skills/%s", ss.project, name))
+	if err != nil {
+		return fmt.Errorf("error deleting skill: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func (ss *Skills) _checkSkill(ctx context.Context, skill interface{}) (string, error) {
+	switch skill := skill.(type) {
+	case string:
+		return skill, nil
+	case *Skill:
+		return skill.Name, nil
+	case *SkillConfig:
+		if _, err := ss.GetContext(ctx, skill.Name); err != nil {
+			if errors.As(err, new(*ObjectNotFound)) {
+				if _, err := ss.CreateContext(ctx, skill, false); err != nil {
+					return "", fmt.Errorf("error creating skill: %w", err)
+				}
+			} else {
+				return "", fmt.Errorf("error checking for existing skill: %w", err)
+			}
+		}
+		return skill.Name, nil
+	default:
+		return "", fmt.Errorf("unknown skill type: %T", skill)
+	}
+}
@@ -0,0 +1,80 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// DEFAULT_DATABASE is the database SQL queries run against unless they
+// qualify their table names.
+const DEFAULT_DATABASE = "mindsdb"
+
+// QueryColumn describes a column of a SQL query result.
+type QueryColumn struct {
+	Name string
+	// Type is the column type reported by the server, if any.
+	Type string
+}
+
+// QueryResult holds the result of a SQL query. Numbers in Data are decoded
+// as json.Number to keep integer precision.
+type QueryResult struct {
+	Columns []*QueryColumn
+	Data    [][]interface{}
+}
+
+type queryResponse struct {
+	Type         string          `json:"type"`
+	ColumnNames  []string        `json:"column_names"`
+	ColumnTypes  []string        `json:"column_types"`
+	Data         [][]interface{} `json:"data"`
+	ErrorCode    int             `json:"error_code"`
+	ErrorMessage string          `json:"error_message"`
+}
+
+// Query runs a SQL statement through the MindsDB SQL API. Statements that do
+// not return rows produce an empty result. Failed statements are returned as
+// SQLError.
+func (c *Client) Query(ctx context.Context, sql string) (*QueryResult, error) {
+	return c.QueryDatabase(ctx, DEFAULT_DATABASE, sql)
+}
+
+// QueryDatabase runs a SQL statement with database as the current database.
+func (c *Client) QueryDatabase(ctx context.Context, database string, sql string) (_ *QueryResult, err error) {
+	ctx, span := c.api._startSpan(ctx, "Client.Query", AttrProject, database)
+	defer func() { _endSpan(span, err) }()
+
+	data := map[string]interface{}{
+		"query":   sql,
+		"context": map[string]string{"db": database},
+	}
+	resp, err := c.api.post(ctx, "/sql/query", data)
+	if err != nil {
+		return nil, fmt.Errorf("error running query: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var qr queryResponse
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&qr); err != nil {
+		return nil, fmt.Errorf("error decoding query response: %w", err)
+	}
+	if qr.Type == "error" {
+		return nil, &SQLError{Code: qr.ErrorCode, Message: qr.ErrorMessage}
+	}
+
+	result := &QueryResult{
+		Columns: make([]*QueryColumn, 0, len(qr.ColumnNames)),
+		Data:    qr.Data,
+	}
+	for i, name := range qr.ColumnNames {
+		column := &QueryColumn{Name: name}
+		if i < len(qr.ColumnTypes) {
+			column.Type = qr.ColumnTypes[i]
+		}
+		result.Columns = append(result.Columns, column)
+	}
+	return result, nil
+}
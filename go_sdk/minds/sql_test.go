@@ -0,0 +1,135 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// sqlServer answers /api/sql/query with response and keeps the last request.
+func sqlServer(t *testing.T, response string, request *map[string]interface{}) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/sql/query" {
+			t.Errorf("request = %s %s, want POST /api/sql/query", r.Method, r.URL.Path)
+		}
+		if request != nil {
+			json.NewDecoder(r.Body).Decode(request)
+		}
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    string
+		wantColumns []*QueryColumn
+		wantData    [][]interface{}
+	}{
+		{
+			name:     "columns and types",
+			response: `{"type": "table", "column_names": ["id", "name"], "column_types": ["int", "varchar"], "data": [[1, "ann"], [2, "bob"]]}`,
+			wantColumns: []*QueryColumn{
+				{Name: "id", Type: "int"},
+				{Name: "name", Type: "varchar"},
+			},
+			wantData: [][]interface{}{
+				{json.Number("1"), "ann"},
+				{json.Number("2"), "bob"},
+			},
+		},
+		{
+			name:        "columns without types",
+			response:    `{"type": "table", "column_names": ["total"], "data": [[12345678901234567890]]}`,
+			wantColumns: []*QueryColumn{{Name: "total"}},
+			wantData:    [][]interface{}{{json.Number("12345678901234567890")}},
+		},
+		{
+			name:        "numbers keep their precision",
+			response:    `{"type": "table", "column_names": ["price"], "column_types": ["float"], "data": [[0.1], [null]]}`,
+			wantColumns: []*QueryColumn{{Name: "price", Type: "float"}},
+			wantData:    [][]interface{}{{json.Number("0.1")}, {nil}},
+		},
+		{
+			name:        "empty result set",
+			response:    `{"type": "table", "column_names": ["id"], "column_types": ["int"], "data": []}`,
+			wantColumns: []*QueryColumn{{Name: "id", Type: "int"}},
+			wantData:    [][]interface{}{},
+		},
+		{
+			name:        "statement without rows",
+			response:    `{"type": "ok"}`,
+			wantColumns: []*QueryColumn{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := sqlServer(t, tt.response, nil)
+			result, err := NewClient("key", srv.URL).Query(context.Background(), "SELECT 1")
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if !reflect.DeepEqual(result.Columns, tt.wantColumns) {
+				t.Errorf("Columns = %+v, want %+v", result.Columns, tt.wantColumns)
+			}
+			if !reflect.DeepEqual(result.Data, tt.wantData) {
+				t.Errorf("Data = %#v, want %#v", result.Data, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestQueryError(t *testing.T) {
+	srv := sqlServer(t, `{"type": "error", "error_code": 1146, "error_message": "Table 'orders' doesn't exist"}`, nil)
+	_, err := NewClient("key", srv.URL).Query(context.Background(), "SELECT * FROM orders")
+
+	var sqlErr *SQLError
+	if !errors.As(err, &sqlErr) {
+		t.Fatalf("Query() error = %v, want SQLError", err)
+	}
+	if sqlErr.Code != 1146 || sqlErr.Message != "Table 'orders' doesn't exist" {
+		t.Errorf("SQLError = %+v", sqlErr)
+	}
+}
+
+func TestQueryDatabase(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  func(c *Client) (*QueryResult, error)
+		wantDB string
+	}{
+		{
+			name:   "default database",
+			query:  func(c *Client) (*QueryResult, error) { return c.Query(context.Background(), "SHOW TABLES") },
+			wantDB: DEFAULT_DATABASE,
+		},
+		{
+			name:   "given database",
+			query:  func(c *Client) (*QueryResult, error) { return c.QueryDatabase(context.Background(), "sales", "SHOW TABLES") },
+			wantDB: "sales",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request map[string]interface{}
+			srv := sqlServer(t, `{"type": "ok"}`, &request)
+			if _, err := tt.query(NewClient("key", srv.URL)); err != nil {
+				t.Fatalf("query error = %v", err)
+			}
+			want := map[string]interface{}{
+				"query":   "SHOW TABLES",
+				"context": map[string]interface{}{"db": tt.wantDB},
+			}
+			if !reflect.DeepEqual(request, want) {
+				t.Errorf("request = %v, want %v", request, want)
+			}
+		})
+	}
+}
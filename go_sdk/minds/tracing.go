@@ -0,0 +1,144 @@
+package minds
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Span attribute keys set by the client.
+const (
+	AttrMind          = "minds.mind"
+	AttrDatasource    = "minds.datasource"
+	AttrFile          = "minds.file"
+	AttrKnowledgeBase = "minds.knowledge_base"
+	AttrModel         = "minds.model"
+	AttrJob           = "minds.job"
+	AttrView          = "minds.view"
+	AttrAgent         = "minds.agent"
+	AttrSkill         = "minds.skill"
+	AttrProject       = "minds.project"
+	AttrStream        = "minds.stream"
+	AttrHTTPMethod    = "http.method"
+	AttrHTTPPath      = "http.path"
+	AttrHTTPStatus    = "http.status_code"
+	AttrRetryCount    = "minds.retry_count"
+)
+
+// Tracer starts spans for SDK operations. Implementations can bridge to a
+// tracing system such as OpenTelemetry. Spans for nested operations, like
+// the Get performed by Minds.Create, and for each HTTP request are started
+// with the parent span's context.
+type Tracer interface {
+	StartSpan(ctx context.Context, operation string) (context.Context, Span)
+}
+
+// Span is a single traced operation.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// WithTracer sets the tracer spans are reported to.
+func WithTracer(tracer Tracer) Option {
+	return func(r *RestAPI) {
+		r.Tracer = tracer
+	}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+func (noopSpan) RecordError(err error)                      {}
+func (noopSpan) End()                                       {}
+
+// _startSpan starts a span for an SDK operation with the given key/value
+// attributes. The operation's duration and outcome are also reported to the
+// client's metrics.
+func (r *RestAPI) _startSpan(ctx context.Context, operation string, attrs ...interface{}) (context.Context, Span) {
+	ctx, span := r._startTrace(ctx, operation, attrs...)
+	if r.Metrics == nil {
+		return ctx, span
+	}
+	return ctx, &metricsSpan{Span: span, metrics: r.Metrics, operation: operation, start: time.Now()}
+}
+
+// _startTrace starts a span that is only reported to the tracer.
+func (r *RestAPI) _startTrace(ctx context.Context, operation string, attrs ...interface{}) (context.Context, Span) {
+	if r.Tracer == nil {
+		return ctx, noopSpan{}
+	}
+	ctx, span := r.Tracer.StartSpan(ctx, operation)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		if key, ok := attrs[i].(string); ok {
+			span.SetAttribute(key, attrs[i+1])
+		}
+	}
+	return ctx, span
+}
+
+// _endSpan records err, if any, and ends span.
+func _endSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+type requestStateKey struct{}
+
+// requestState tracks a single HTTP request as it passes through the
+// transport layers.
+type requestState struct {
+	retries int
+}
+
+func _requestState(ctx context.Context) *requestState {
+	state, _ := ctx.Value(requestStateKey{}).(*requestState)
+	return state
+}
+
+// _countRetry records that the request carrying ctx is being retried.
+func _countRetry(ctx context.Context) {
+	if state := _requestState(ctx); state != nil {
+		state.retries++
+	}
+}
+
+// tracingTransport reports a span for each HTTP request with its method,
+// path, status code and retry count.
+type tracingTransport struct {
+	api  *RestAPI
+	base http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	state := _requestState(ctx)
+	if state == nil {
+		state = &requestState{}
+		ctx = context.WithValue(ctx, requestStateKey{}, state)
+	}
+	ctx, span := t.api._startTrace(ctx, "HTTP "+req.Method,
+		AttrHTTPMethod, req.Method,
+		AttrHTTPPath, _apiPath(req.URL.Path),
+	)
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if resp != nil {
+		span.SetAttribute(AttrHTTPStatus, resp.StatusCode)
+	}
+	span.SetAttribute(AttrRetryCount, state.retries)
+	_endSpan(span, err)
+	return resp, err
+}
+
+// _apiPath strips everything up to the /api prefix from a request path.
+func _apiPath(path string) string {
+	if i := strings.Index(path, "/api/"); i >= 0 {
+		return path[i+len("/api"):]
+	}
+	return path
+}
@@ -0,0 +1,77 @@
+package minds
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type parentKey struct{}
+
+type testSpan struct {
+	operation string
+	parent    string
+	err       error
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) {}
+func (s *testSpan) RecordError(err error)                      { s.err = err }
+func (s *testSpan) End()                                       {}
+
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) StartSpan(ctx context.Context, operation string) (context.Context, Span) {
+	parent, _ := ctx.Value(parentKey{}).(string)
+	span := &testSpan{operation: operation, parent: parent}
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return context.WithValue(ctx, parentKey{}, operation), span
+}
+
+func TestContextMethodsJoinCallerTrace(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	tracer := &testTracer{}
+	client := NewClient("key", srv.URL, WithTracer(tracer))
+	ctx := context.WithValue(context.Background(), parentKey{}, "caller")
+	if _, err := client.Datasources.ListContext(ctx); err != nil {
+		t.Fatalf("ListContext: %v", err)
+	}
+
+	want := []struct{ operation, parent string }{
+		{"Datasources.List", "caller"},
+		{"HTTP GET", "Datasources.List"},
+	}
+	if len(tracer.spans) != len(want) {
+		t.Fatalf("got %d spans, want %d", len(tracer.spans), len(want))
+	}
+	for i, w := range want {
+		if got := tracer.spans[i]; got.operation != w.operation || got.parent != w.parent {
+			t.Errorf("span %d = %s (parent %q), want %s (parent %q)", i, got.operation, got.parent, w.operation, w.parent)
+		}
+	}
+}
+
+func TestContextMethodsCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", srv.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Minds.ListContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ListContext error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,109 @@
+package minds
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// TransportConfig holds the network settings shared by REST, completion and
+// login traffic. Build a transport from it with NewTransport and pass that to
+// WithTransport.
+type TransportConfig struct {
+	// CAFile and CAPEM add certificate authorities, PEM encoded, to the
+	// system pool used to verify the server.
+	CAFile string
+	CAPEM  []byte
+	// CertFile and KeyFile hold a client certificate for mutual TLS.
+	CertFile string
+	KeyFile  string
+	// Certificates are client certificates used in addition to CertFile.
+	Certificates []tls.Certificate
+	// ServerName overrides the name used to verify the server certificate.
+	ServerName string
+	// InsecureSkipVerify disables server certificate verification. It is
+	// meant for local development only.
+	InsecureSkipVerify bool
+	// Proxy is the URL of an HTTP or HTTPS proxy. When empty, the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables apply.
+	Proxy string
+	// UnixSocket, if set, is dialed for every connection, for a local
+	// MindsDB listening on a Unix socket.
+	UnixSocket string
+	// DialContext, if set, opens connections instead of the default dialer.
+	DialContext func(ctx context.Context, network string, addr string) (net.Conn, error)
+}
+
+// NewTransport builds an *http.Transport with the configured settings on top
+// of http.DefaultTransport's defaults.
+func (c *TransportConfig) NewTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := &tls.Config{
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+		Certificates:       c.Certificates,
+	}
+	if c.CAFile != "" || len(c.CAPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if c.CAFile != "" {
+			pem, err := os.ReadFile(c.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("error reading CA file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, &InvalidConfig{Message: fmt.Sprintf("no certificates found in %s", c.CAFile)}
+			}
+		}
+		if len(c.CAPEM) > 0 && !pool.AppendCertsFromPEM(c.CAPEM) {
+			return nil, &InvalidConfig{Message: "no certificates found in CA PEM"}
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if c.Proxy != "" {
+		proxyURL, err := url.Parse(c.Proxy)
+		if err != nil {
+			return nil, &InvalidConfig{Message: fmt.Sprintf("invalid proxy URL %q: %v", c.Proxy, err)}
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	switch {
+	case c.DialContext != nil:
+		transport.DialContext = c.DialContext
+	case c.UnixSocket != "":
+		socket := c.UnixSocket
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+		// Connections never leave the machine.
+		transport.Proxy = nil
+	}
+	return transport, nil
+}
+
+// WithTransport sets the base transport of the client's REST and completion
+// requests, and of SessionAuth logins that have no transport of their own.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *RestAPI) {
+		r.Client.Transport = transport
+	}
+}
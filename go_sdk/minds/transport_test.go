@@ -0,0 +1,79 @@
+package minds
+
+import (
+	"encoding/pem"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestTransportConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config TransportConfig
+	}{
+		{"CA PEM without certificates", TransportConfig{CAPEM: []byte("not a certificate")}},
+		{"missing CA file", TransportConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")}},
+		{"missing client certificate", TransportConfig{CertFile: "missing.crt", KeyFile: "missing.key"}},
+		{"invalid proxy", TransportConfig{Proxy: "http://[::1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.config.NewTransport(); err == nil {
+				t.Error("NewTransport() returned no error")
+			}
+		})
+	}
+}
+
+func TestTransportConfigCA(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	transport, err := (&TransportConfig{CAPEM: caPEM}).NewTransport()
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+	if _, err := NewClient("key", srv.URL, WithTransport(transport)).Skills.List(); err != nil {
+		t.Errorf("List() with the server's CA error = %v", err)
+	}
+
+	transport, _ = (&TransportConfig{}).NewTransport()
+	if _, err := NewClient("key", srv.URL, WithTransport(transport)).Skills.List(); err == nil {
+		t.Error("List() without the server's CA returned no error")
+	}
+}
+
+func TestTransportConfigUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "mindsdb.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("Unix sockets are not available: %v", err)
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	srv.Listener = listener
+	srv.Start()
+	defer srv.Close()
+
+	transport, err := (&TransportConfig{UnixSocket: socket}).NewTransport()
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+	if _, err := NewClient("key", "http://mindsdb", WithTransport(transport)).Skills.List(); err != nil {
+		t.Errorf("List() over the socket error = %v", err)
+	}
+
+	var opErr *net.OpError
+	transport, _ = (&TransportConfig{UnixSocket: filepath.Join(t.TempDir(), "none.sock")}).NewTransport()
+	if _, err := NewClient("key", "http://mindsdb", WithTransport(transport)).Skills.List(); !errors.As(err, &opErr) {
+		t.Errorf("List() over a missing socket error = %v, want a dial error", err)
+	}
+}
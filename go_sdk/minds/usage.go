@@ -0,0 +1,256 @@
+package minds
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Usage holds the tokens consumed by a completion.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+// TotalTokens returns the sum of prompt and completion tokens.
+func (u Usage) TotalTokens() int {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// Price is the cost of 1000 prompt and completion tokens.
+type Price struct {
+	PromptPer1K     float64
+	CompletionPer1K float64
+}
+
+// Cost returns the estimated cost of usage at price p.
+func (p Price) Cost(usage Usage) float64 {
+	return float64(usage.PromptTokens)/1000*p.PromptPer1K + float64(usage.CompletionTokens)/1000*p.CompletionPer1K
+}
+
+// UsageTotals aggregates the usage of a set of completions.
+type UsageTotals struct {
+	Calls            int
+	PromptTokens     int
+	CompletionTokens int
+	Cost             float64
+}
+
+func (t *UsageTotals) add(usage Usage, cost float64) {
+	t.Calls++
+	t.PromptTokens += usage.PromptTokens
+	t.CompletionTokens += usage.CompletionTokens
+	t.Cost += cost
+}
+
+// WindowUsage is the usage recorded in the window starting at Start.
+type WindowUsage struct {
+	Start time.Time
+	UsageTotals
+}
+
+// UsageSnapshot is a point-in-time copy of the recorded usage.
+type UsageSnapshot struct {
+	Total UsageTotals
+	// ByMind is keyed by mind or agent name.
+	ByMind map[string]UsageTotals
+	// ByTag is keyed by caller tag; untagged calls are under "".
+	ByTag map[string]UsageTotals
+	// ByWindow is ordered by window start.
+	ByWindow []WindowUsage
+}
+
+// UsageAccountant records the token usage and estimated cost of completions
+// and can reject new completions once a budget is spent.
+type UsageAccountant struct {
+	// Prices maps mind, agent or LLM model names to prices. A mind's own
+	// name takes precedence over its model name.
+	Prices map[string]Price
+	// DefaultPrice applies to completions without an entry in Prices.
+	DefaultPrice Price
+	// Window is the length of the time windows usage is grouped in.
+	// It defaults to one hour.
+	Window time.Duration
+	// Retention is how long the usage of past windows is kept. It defaults
+	// to 24 windows.
+	Retention time.Duration
+	// Budget is the maximum total cost; zero means no limit.
+	Budget float64
+	// TokenBudget is the maximum number of total tokens; zero means no limit.
+	TokenBudget int
+	// WindowBudget is the maximum cost within the current window; zero
+	// means no limit.
+	WindowBudget float64
+	// WindowTokenBudget is the maximum number of tokens within the current
+	// window; zero means no limit.
+	WindowTokenBudget int
+
+	mu      sync.Mutex
+	total   UsageTotals
+	minds   map[string]*UsageTotals
+	tags    map[string]*UsageTotals
+	windows map[time.Time]*UsageTotals
+}
+
+// NewUsageAccountant creates a UsageAccountant using the given price table.
+func NewUsageAccountant(prices map[string]Price) *UsageAccountant {
+	return &UsageAccountant{Prices: prices}
+}
+
+// WithUsageAccountant records completion usage in accountant and enforces
+// its budget.
+func WithUsageAccountant(accountant *UsageAccountant) Option {
+	return func(r *RestAPI) {
+		r.Usage = accountant
+	}
+}
+
+// Record adds the usage of a completion by mind, made with model and
+// attributed to tag. It returns the estimated cost.
+func (a *UsageAccountant) Record(mind string, model string, tag string, usage Usage) float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cost := a._price(mind, model).Cost(usage)
+	a.total.add(usage, cost)
+	_totalsFor(&a.minds, mind).add(usage, cost)
+	_totalsFor(&a.tags, tag).add(usage, cost)
+
+	if a.windows == nil {
+		a.windows = make(map[time.Time]*UsageTotals)
+	}
+	now := time.Now()
+	start := now.Truncate(a._window())
+	if a.windows[start] == nil {
+		a.windows[start] = &UsageTotals{}
+		a._prune(now)
+	}
+	a.windows[start].add(usage, cost)
+	return cost
+}
+
+// Check returns BudgetExceeded if the total or current window's cost or
+// token budget has been spent.
+func (a *UsageAccountant) Check() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.Budget > 0 && a.total.Cost >= a.Budget {
+		return &BudgetExceeded{Message: fmt.Sprintf("spent %.4f of budget %.4f", a.total.Cost, a.Budget)}
+	}
+	tokens := a.total.PromptTokens + a.total.CompletionTokens
+	if a.TokenBudget > 0 && tokens >= a.TokenBudget {
+		return &BudgetExceeded{Message: fmt.Sprintf("used %d of %d tokens", tokens, a.TokenBudget)}
+	}
+
+	window, ok := a.windows[time.Now().Truncate(a._window())]
+	if !ok {
+		return nil
+	}
+	if a.WindowBudget > 0 && window.Cost >= a.WindowBudget {
+		return &BudgetExceeded{Message: fmt.Sprintf("spent %.4f of window budget %.4f", window.Cost, a.WindowBudget)}
+	}
+	tokens = window.PromptTokens + window.CompletionTokens
+	if a.WindowTokenBudget > 0 && tokens >= a.WindowTokenBudget {
+		return &BudgetExceeded{Message: fmt.Sprintf("used %d of %d window tokens", tokens, a.WindowTokenBudget)}
+	}
+	return nil
+}
+
+// Snapshot returns a copy of the usage recorded so far.
+func (a *UsageAccountant) Snapshot() *UsageSnapshot {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	snapshot := &UsageSnapshot{
+		Total:  a.total,
+		ByMind: make(map[string]UsageTotals, len(a.minds)),
+		ByTag:  make(map[string]UsageTotals, len(a.tags)),
+	}
+	for name, totals := range a.minds {
+		snapshot.ByMind[name] = *totals
+	}
+	for tag, totals := range a.tags {
+		snapshot.ByTag[tag] = *totals
+	}
+	a._prune(time.Now())
+	for start, totals := range a.windows {
+		snapshot.ByWindow = append(snapshot.ByWindow, WindowUsage{Start: start, UsageTotals: *totals})
+	}
+	sort.Slice(snapshot.ByWindow, func(i, j int) bool {
+		return snapshot.ByWindow[i].Start.Before(snapshot.ByWindow[j].Start)
+	})
+	return snapshot
+}
+
+// Reset clears the recorded usage, which also restores the budget.
+func (a *UsageAccountant) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.total = UsageTotals{}
+	a.minds = nil
+	a.tags = nil
+	a.windows = nil
+}
+
+func (a *UsageAccountant) _price(mind string, model string) Price {
+	if price, ok := a.Prices[mind]; ok {
+		return price
+	}
+	if price, ok := a.Prices[model]; ok {
+		return price
+	}
+	return a.DefaultPrice
+}
+
+func (a *UsageAccountant) _window() time.Duration {
+	if a.Window <= 0 {
+		return time.Hour
+	}
+	return a.Window
+}
+
+// _prune drops the windows that ended more than Retention before now.
+func (a *UsageAccountant) _prune(now time.Time) {
+	retention := a.Retention
+	if retention <= 0 {
+		retention = 24 * a._window()
+	}
+	cutoff := now.Add(-retention)
+	for start := range a.windows {
+		if start.Add(a._window()).Before(cutoff) {
+			delete(a.windows, start)
+		}
+	}
+}
+
+func _totalsFor(totals *map[string]*UsageTotals, key string) *UsageTotals {
+	if *totals == nil {
+		*totals = make(map[string]*UsageTotals)
+	}
+	if (*totals)[key] == nil {
+		(*totals)[key] = &UsageTotals{}
+	}
+	return (*totals)[key]
+}
+
+// usageLabels describes a completion for usage accounting.
+type usageLabels struct {
+	model string
+	tag   string
+}
+
+type usageLabelsKey struct{}
+
+// _withUsageLabels attaches the model name and caller tag of a completion to ctx.
+func _withUsageLabels(ctx context.Context, model string, tag string) context.Context {
+	return context.WithValue(ctx, usageLabelsKey{}, usageLabels{model: model, tag: tag})
+}
+
+func _usageLabels(ctx context.Context) usageLabels {
+	labels, _ := ctx.Value(usageLabelsKey{}).(usageLabels)
+	return labels
+}
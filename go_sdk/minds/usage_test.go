@@ -0,0 +1,63 @@
+package minds
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUsageAccountantCheck(t *testing.T) {
+	usage := Usage{PromptTokens: 600, CompletionTokens: 400}
+	tests := []struct {
+		name       string
+		accountant *UsageAccountant
+		wantErr    bool
+	}{
+		{"no budget", &UsageAccountant{}, false},
+		{"within budget", &UsageAccountant{Budget: 5}, false},
+		{"budget spent", &UsageAccountant{Budget: 1}, true},
+		{"token budget spent", &UsageAccountant{TokenBudget: 1000}, true},
+		{"within window budget", &UsageAccountant{WindowBudget: 5}, false},
+		{"window budget spent", &UsageAccountant{WindowBudget: 1}, true},
+		{"window token budget spent", &UsageAccountant{WindowTokenBudget: 1000}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.accountant.DefaultPrice = Price{PromptPer1K: 1, CompletionPer1K: 1}
+			tt.accountant.Record("m", "gpt", "", usage)
+			err := tt.accountant.Check()
+			var exceeded *BudgetExceeded
+			if tt.wantErr != errors.As(err, &exceeded) {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUsageAccountantWindowBudgetIgnoresPastWindows(t *testing.T) {
+	accountant := &UsageAccountant{WindowTokenBudget: 100}
+	past := time.Now().Truncate(time.Hour).Add(-time.Hour)
+	accountant.windows = map[time.Time]*UsageTotals{past: {Calls: 1, PromptTokens: 500}}
+
+	if err := accountant.Check(); err != nil {
+		t.Errorf("Check() error = %v, want the past window to be ignored", err)
+	}
+}
+
+func TestUsageAccountantRetention(t *testing.T) {
+	accountant := &UsageAccountant{Window: time.Minute, Retention: 10 * time.Minute}
+	now := time.Now().Truncate(time.Minute)
+	accountant.windows = map[time.Time]*UsageTotals{
+		now.Add(-30 * time.Minute): {Calls: 1},
+		now.Add(-5 * time.Minute):  {Calls: 1},
+	}
+	accountant.Record("m", "", "", Usage{PromptTokens: 1})
+
+	snapshot := accountant.Snapshot()
+	if len(snapshot.ByWindow) != 2 {
+		t.Fatalf("ByWindow = %+v, want 2 windows", snapshot.ByWindow)
+	}
+	if !snapshot.ByWindow[0].Start.Equal(now.Add(-5 * time.Minute)) {
+		t.Errorf("oldest window = %v, want %v", snapshot.ByWindow[0].Start, now.Add(-5*time.Minute))
+	}
+}
@@ -0,0 +1,173 @@
+package minds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// View represents a MindsDB view, a saved query over one or more datasources.
+type View struct {
+	api     *RestAPI
+	Project string `json:"project"`
+	Name    string `json:"name"`
+	Query   string `json:"query"`
+}
+
+// Update replaces the view's query.
+func (v *View) Update(query string) error {
+	return v.UpdateContext(context.Background(), query)
+}
+
+// UpdateContext is like Update with a context.
+func (v *View) UpdateContext(ctx context.Context, query string) (err error) {
+	ctx, span := v.api._startSpan(ctx, "View.Update", AttrView, v.Name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := v.api.put(
+		ctx,
+		fmt.Sprintf("/projects/%s/views/%s", v.Project, v.Name),
+		map[string]interface{}{"view": map[string]string{"query": query}},
+	)
+	if err != nil {
+		return fmt.Errorf("error updating view: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	v.Query = query
+	return nil
+}
+
+// Views manages the views of a project.
+type Views struct {
+	api     *RestAPI
+	project string
+}
+
+// NewViews creates a new Views instance scoped to project.
+func NewViews(client *Client, project string) *Views {
+	return &Views{
+		api:     client.api,
+		project: project,
+	}
+}
+
+// Create creates a view from a SQL query.
+func (vs *Views) Create(name string, query string, replace bool) (*View, error) {
+	return vs.CreateContext(context.Background(), name, query, replace)
+}
+
+// CreateContext is like Create with a context.
+func (vs *Views) CreateContext(ctx context.Context, name string, query string, replace bool) (_ *View, err error) {
+	ctx, span := vs.api._startSpan(ctx, "Views.Create", AttrView, name)
+	defer func() { _endSpan(span, err) }()
+
+	if replace {
+		_, err := vs.GetContext(ctx, name)
+		if err == nil {
+			err = vs.DropContext(ctx, name)
+			if err != nil {
+				return nil, fmt.Errorf("error replacing view: %w", err)
+			}
+		} else if !errors.As(err, new(*ObjectNotFound)) {
+			return nil, fmt.Errorf("error checking for existing view: %w", err)
+		}
+	}
+
+	resp, err := vs.api.post(
+		ctx,
+		fmt.Sprintf("/projects/%s/views", vs.project),
+		map[string]interface{}{"view": map[string]string{"name": name, "query": query}},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating view: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return vs.GetContext(ctx, name)
+}
+
+// List returns all views in the project.
+func (vs *Views) List() ([]*View, error) {
+	return vs.ListContext(context.Background())
+}
+
+// ListContext is like List with a context.
+func (vs *Views) ListContext(ctx context.Context) (_ []*View, err error) {
+	ctx, span := vs.api._startSpan(ctx, "Views.List")
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := vs.api.get(ctx, fmt.Sprintf("/projects/%s/views", vs.project))
+	if err != nil {
+		return nil, fmt.Errorf("error listing views: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var data []*View
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("error decoding views response: %w", err)
+	}
+	for _, view := range data {
+		view.api = vs.api
+		view.Project = vs.project
+	}
+	return data, nil
+}
+
+// Get retrieves a view and its definition by name.
+func (vs *Views) Get(name string) (*View, error) {
+	return vs.GetContext(context.Background(), name)
+}
+
+// GetContext is like Get with a context.
+func (vs *Views) GetContext(ctx context.Context, name string) (_ *View, err error) {
+	ctx, span := vs.api._startSpan(ctx, "Views.Get", AttrView, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := vs.api.get(ctx, fmt.Sprintf("/projects/%s/views/%s", vs.project, name))
+	if err != nil {
+		return nil, fmt.Errorf("error getting view: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var view View
+	if err := json.NewDecoder(resp.Body).Decode(&view); err != nil {
+		return nil, fmt.Errorf("error decoding view response: %w", err)
+	}
+	view.api = vs.api
+	view.Project = vs.project
+	return &view, nil
+}
+
+// Drop deletes a view by name.
+func (vs *Views) Drop(name string) error {
+	return vs.DropContext(context.Background(), name)
+}
+
+// DropContext is like Drop with a context.
+func (vs *Views) DropContext(ctx context.Context, name string) (err error) {
+	ctx, span := vs.api._startSpan(ctx, "Views.Drop", AttrView, name)
+	defer func() { _endSpan(span, err) }()
+
+	resp, err := vs.api.delete(ctx, fmt.Sprintf("/projects/%s/views/%s", vs.project, name))
+	if err != nil {
+		return fmt.Errorf("error deleting view: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package minds
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestViewsCreateReplaces(t *testing.T) {
+	srv, requests := recordingServer(t, map[string]string{
+		"GET /api/projects/sales/views/recent": `{"name": "recent", "query": "SELECT * FROM orders LIMIT 10"}`,
+	})
+	view, err := NewViews(NewClient("key", srv.URL), "sales").Create("recent", "SELECT * FROM orders LIMIT 10", true)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	want := []recordedRequest{
+		{Method: http.MethodGet, Path: "/api/projects/sales/views/recent"},
+		{Method: http.MethodDelete, Path: "/api/projects/sales/views/recent"},
+		{
+			Method: http.MethodPost,
+			Path:   "/api/projects/sales/views",
+			Body:   map[string]interface{}{"view": map[string]interface{}{"name": "recent", "query": "SELECT * FROM orders LIMIT 10"}},
+		},
+		{Method: http.MethodGet, Path: "/api/projects/sales/views/recent"},
+	}
+	if !reflect.DeepEqual(*requests, want) {
+		t.Errorf("requests = %+v, want %+v", *requests, want)
+	}
+	if view.Project != "sales" || view.Query != "SELECT * FROM orders LIMIT 10" {
+		t.Errorf("view = %+v", view)
+	}
+}
+
+func TestViewUpdate(t *testing.T) {
+	srv, requests := recordingServer(t, nil)
+	view := &View{api: NewClient("key", srv.URL).api, Project: "sales", Name: "recent", Query: "SELECT 1"}
+
+	if err := view.Update("SELECT * FROM orders LIMIT 5"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	want := []recordedRequest{{
+		Method: http.MethodPut,
+		Path:   "/api/projects/sales/views/recent",
+		Body:   map[string]interface{}{"view": map[string]interface{}{"query": "SELECT * FROM orders LIMIT 5"}},
+	}}
+	if !reflect.DeepEqual(*requests, want) {
+		t.Errorf("requests = %+v, want %+v", *requests, want)
+	}
+	if view.Query != "SELECT * FROM orders LIMIT 5" {
+		t.Errorf("Query = %q, want the new query", view.Query)
+	}
+}
@@ -0,0 +1,179 @@
+// Package sqldriver provides a database/sql driver for MindsDB built on the
+// SDK's SQL API client.
+//
+//	db, err := sql.Open("mindsdb", "https://<API_KEY>@mdb.ai/mindsdb")
+//
+// The user part of the DSN is the API key, the path selects the current
+// database and the mindsdb:// scheme is an alias for https://.
+package sqldriver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go_sdk/minds"
+)
+
+func init() {
+	sql.Register("mindsdb", &Driver{})
+}
+
+// Driver implements driver.Driver for MindsDB.
+type Driver struct{}
+
+// Open opens a connection described by dsn.
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	connector, err := d.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(context.Background())
+}
+
+// OpenConnector parses dsn into a connector sharing one SDK client.
+func (d *Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	apiKey, baseURL, database, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &connector{
+		client:   minds.NewClient(apiKey, baseURL),
+		database: database,
+		driver:   d,
+	}, nil
+}
+
+// NewConnector returns a connector that runs queries through an existing
+// client, for use with sql.OpenDB. An empty database selects the default one.
+func NewConnector(client *minds.Client, database string) driver.Connector {
+	if database == "" {
+		database = minds.DEFAULT_DATABASE
+	}
+	return &connector{client: client, database: database, driver: &Driver{}}
+}
+
+func parseDSN(dsn string) (apiKey string, baseURL string, database string, err error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", "", fmt.Errorf("mindsdb: invalid dsn: %w", err)
+	}
+	switch u.Scheme {
+	case "mindsdb":
+		u.Scheme = "https"
+	case "http", "https":
+	default:
+		return "", "", "", fmt.Errorf("mindsdb: unsupported dsn scheme %q", u.Scheme)
+	}
+	if u.User != nil {
+		apiKey = u.User.Username()
+	}
+	database = strings.Trim(u.Path, "/")
+	if database == "" {
+		database = minds.DEFAULT_DATABASE
+	}
+	return apiKey, u.Scheme + "://" + u.Host, database, nil
+}
+
+type connector struct {
+	client   *minds.Client
+	database string
+	driver   *Driver
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &conn{client: c.client, database: c.database}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// conn is stateless: every query is a separate HTTP request.
+type conn struct {
+	client   *minds.Client
+	database string
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, errors.New("mindsdb: transactions are not supported")
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	_, err := c.client.QueryDatabase(ctx, c.database, "SELECT 1")
+	return err
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	query, err := interpolate(query, args)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.client.QueryDatabase(ctx, c.database, query)
+	if err != nil {
+		return nil, err
+	}
+	return newRows(result), nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	query, err := interpolate(query, args)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.client.QueryDatabase(ctx, c.database, query); err != nil {
+		return nil, err
+	}
+	// The SQL API does not report affected rows or insert IDs.
+	return driver.ResultNoRows, nil
+}
+
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+// NumInput returns -1 as placeholders are not counted ahead of execution.
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
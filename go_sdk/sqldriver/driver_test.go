@@ -0,0 +1,163 @@
+package sqldriver
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go_sdk/minds"
+)
+
+func TestParseDSN(t *testing.T) {
+	tests := []struct {
+		dsn          string
+		wantKey      string
+		wantURL      string
+		wantDatabase string
+		wantErr      string
+	}{
+		{dsn: "https://key@mdb.ai/sales", wantKey: "key", wantURL: "https://mdb.ai", wantDatabase: "sales"},
+		{dsn: "mindsdb://key@mdb.ai", wantKey: "key", wantURL: "https://mdb.ai", wantDatabase: minds.DEFAULT_DATABASE},
+		{dsn: "http://localhost:47334/", wantURL: "http://localhost:47334", wantDatabase: minds.DEFAULT_DATABASE},
+		{dsn: "postgres://key@mdb.ai/sales", wantErr: "unsupported dsn scheme"},
+		{dsn: "mdb.ai/sales", wantErr: "unsupported dsn scheme"},
+		{dsn: "https://key@mdb.ai/%zz", wantErr: "invalid dsn"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dsn, func(t *testing.T) {
+			apiKey, baseURL, database, err := parseDSN(tt.dsn)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseDSN() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDSN() error = %v", err)
+			}
+			if apiKey != tt.wantKey || baseURL != tt.wantURL || database != tt.wantDatabase {
+				t.Errorf("parseDSN() = %q, %q, %q, want %q, %q, %q", apiKey, baseURL, database, tt.wantKey, tt.wantURL, tt.wantDatabase)
+			}
+		})
+	}
+}
+
+// sqlServer answers every SQL API request with response and records the
+// queries and databases it was sent.
+type sqlServer struct {
+	response  string
+	queries   []string
+	databases []string
+	auth      string
+}
+
+func (s *sqlServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Query   string `json:"query"`
+		Context struct {
+			DB string `json:"db"`
+		} `json:"context"`
+	}
+	json.NewDecoder(r.Body).Decode(&body)
+	s.queries = append(s.queries, body.Query)
+	s.databases = append(s.databases, body.Context.DB)
+	s.auth = r.Header.Get("Authorization")
+	w.Write([]byte(s.response))
+}
+
+func TestQueryContext(t *testing.T) {
+	s := &sqlServer{response: `{"type": "table", "column_names": ["id", "name", "created_at"], "column_types": ["bigint", "varchar", "datetime"], "data": [[1, "ann", "2024-01-02 03:04:05"], [2, null, null]]}`}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	db, err := sql.Open("mindsdb", strings.Replace(srv.URL, "http://", "http://key@", 1)+"/sales")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(context.Background(), "SELECT * FROM users WHERE name <> ? AND id > ?", "it's", 0)
+	if err != nil {
+		t.Fatalf("QueryContext() error = %v", err)
+	}
+	defer rows.Close()
+
+	type user struct {
+		id        int64
+		name      sql.NullString
+		createdAt sql.NullTime
+	}
+	var got []user
+	for rows.Next() {
+		var u user
+		if err := rows.Scan(&u.id, &u.name, &u.createdAt); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		got = append(got, u)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("rows.Err() = %v", err)
+	}
+
+	want := []user{
+		{id: 1, name: sql.NullString{String: "ann", Valid: true}, createdAt: sql.NullTime{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true}},
+		{id: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if want := "SELECT * FROM users WHERE name <> 'it''s' AND id > 0"; s.queries[0] != want {
+		t.Errorf("query = %q, want %q", s.queries[0], want)
+	}
+	if s.databases[0] != "sales" {
+		t.Errorf("database = %q, want sales", s.databases[0])
+	}
+	if s.auth != "Bearer key" {
+		t.Errorf("Authorization = %q, want Bearer key", s.auth)
+	}
+}
+
+func TestExecContext(t *testing.T) {
+	s := &sqlServer{response: `{"type": "ok"}`}
+	srv := httptest.NewServer(s)
+	defer srv.Close()
+
+	db := sql.OpenDB(NewConnector(minds.NewClient("key", srv.URL), ""))
+	defer db.Close()
+
+	result, err := db.ExecContext(context.Background(), "DROP TABLE ?", "t")
+	if err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	if _, err := result.RowsAffected(); err == nil {
+		t.Error("RowsAffected() returned no error, want unsupported")
+	}
+	if s.queries[0] != "DROP TABLE 't'" || s.databases[0] != minds.DEFAULT_DATABASE {
+		t.Errorf("request = %q on %q", s.queries[0], s.databases[0])
+	}
+}
+
+func TestExecContextSQLError(t *testing.T) {
+	srv := httptest.NewServer(&sqlServer{response: `{"type": "error", "error_code": 1146, "error_message": "Table 't' doesn't exist"}`})
+	defer srv.Close()
+
+	db := sql.OpenDB(NewConnector(minds.NewClient("key", srv.URL), "sales"))
+	defer db.Close()
+
+	_, err := db.ExecContext(context.Background(), "DELETE FROM t")
+	var sqlErr *minds.SQLError
+	if !errors.As(err, &sqlErr) || sqlErr.Code != 1146 {
+		t.Errorf("ExecContext() error = %v, want SQLError 1146", err)
+	}
+}
@@ -0,0 +1,92 @@
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go_sdk/internal/sqlvalue"
+)
+
+// interpolate replaces ? placeholders outside of quoted strings, quoted
+// identifiers and comments with SQL literals, as the SQL API has no bind
+// parameters.
+func interpolate(query string, args []driver.NamedValue) (string, error) {
+	if len(args) == 0 {
+		return query, nil
+	}
+	for _, arg := range args {
+		if arg.Name != "" {
+			return "", errors.New("mindsdb: named parameters are not supported")
+		}
+	}
+
+	var b strings.Builder
+	next := 0
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+		switch {
+		case ch == '\'' || ch == '"' || ch == '`':
+			end := skipQuoted(runes, i)
+			b.WriteString(string(runes[i:end]))
+			i = end - 1
+			continue
+		case ch == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			end := skipUntil(runes, i+2, "\n")
+			b.WriteString(string(runes[i:end]))
+			i = end - 1
+			continue
+		case ch == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			end := skipUntil(runes, i+2, "*/")
+			b.WriteString(string(runes[i:end]))
+			i = end - 1
+			continue
+		case ch == '?':
+			if next >= len(args) {
+				return "", fmt.Errorf("mindsdb: not enough arguments for query, got %d", len(args))
+			}
+			b.WriteString(sqlvalue.Literal(args[next].Value))
+			next++
+			continue
+		}
+		b.WriteRune(ch)
+	}
+	if next != len(args) {
+		return "", fmt.Errorf("mindsdb: query has %d placeholders but got %d arguments", next, len(args))
+	}
+	return b.String(), nil
+}
+
+// skipQuoted returns the index just past the quoted string or identifier
+// starting at start. Quotes are escaped by doubling them or, in strings, with
+// a backslash, as sqlvalue.QuoteString does.
+func skipQuoted(runes []rune, start int) int {
+	quote := runes[start]
+	for i := start + 1; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\' && quote != '`':
+			i++
+		case runes[i] == quote:
+			if i+1 < len(runes) && runes[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(runes)
+}
+
+// skipUntil returns the index just past the first occurrence of end at or
+// after start, or the end of the query.
+func skipUntil(runes []rune, start int, end string) int {
+	target := []rune(end)
+	for i := start; i+len(target) <= len(runes); i++ {
+		if string(runes[i:i+len(target)]) == end {
+			return i + len(target)
+		}
+	}
+	return len(runes)
+}
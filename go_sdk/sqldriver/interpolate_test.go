@@ -0,0 +1,74 @@
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+func TestInterpolate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		args    []interface{}
+		want    string
+		wantErr bool
+	}{
+		{"no args", "SELECT 1", nil, "SELECT 1", false},
+		{"values", "SELECT * FROM t WHERE a = ? AND b = ? AND c = ?", []interface{}{int64(1), "x", nil}, "SELECT * FROM t WHERE a = 1 AND b = 'x' AND c = NULL", false},
+		{"quotes escaped", "SELECT ?", []interface{}{`it's a \ test`}, `SELECT 'it''s a \\ test'`, false},
+		{"placeholder in string", "SELECT '?' , ?", []interface{}{int64(2)}, "SELECT '?' , 2", false},
+		{"backslash-escaped quote", `SELECT 'it\'s ?' , ?`, []interface{}{int64(3)}, `SELECT 'it\'s ?' , 3`, false},
+		{"doubled quote", "SELECT 'it''s ?' , ?", []interface{}{int64(4)}, "SELECT 'it''s ?' , 4", false},
+		{"quoted identifier", "SELECT `a?b`, ?", []interface{}{true}, "SELECT `a?b`, TRUE", false},
+		{"line comment", "SELECT ? -- don't ?\nFROM t", []interface{}{int64(5)}, "SELECT 5 -- don't ?\nFROM t", false},
+		{"block comment", "SELECT /* it's ? */ ?", []interface{}{int64(6)}, "SELECT /* it's ? */ 6", false},
+		{"bytes", "SELECT ?", []interface{}{[]byte{0xde, 0xad}}, "SELECT x'dead'", false},
+		{"time", "SELECT ?", []interface{}{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}, "SELECT '2024-01-02 03:04:05'", false},
+		{"too few args", "SELECT ?, ?", []interface{}{int64(1)}, "", true},
+		{"too many args", "SELECT ?", []interface{}{int64(1), int64(2)}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make([]driver.NamedValue, len(tt.args))
+			for i, v := range tt.args {
+				args[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+			}
+			got, err := interpolate(tt.query, args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("interpolate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("interpolate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpolateRejectsNamedArgs(t *testing.T) {
+	_, err := interpolate("SELECT ?", []driver.NamedValue{{Name: "a", Value: int64(1)}})
+	if err == nil {
+		t.Fatal("interpolate() accepted a named argument")
+	}
+}
+
+func TestTypeClass(t *testing.T) {
+	tests := map[string]string{
+		"INT":              "int",
+		"bigint unsigned":  "int",
+		"VARCHAR(255)":     "string",
+		"double precision": "float",
+		"DECIMAL(10,2)":    "float",
+		"timestamp":        "time",
+		"BOOLEAN":          "bool",
+		"point":            "",
+		"interval":         "",
+		"json":             "",
+		"":                 "",
+	}
+	for dbType, want := range tests {
+		if got := typeClass(dbType); got != want {
+			t.Errorf("typeClass(%q) = %q, want %q", dbType, got, want)
+		}
+	}
+}
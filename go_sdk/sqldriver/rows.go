@@ -0,0 +1,154 @@
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go_sdk/internal/sqlvalue"
+	"go_sdk/minds"
+)
+
+var (
+	scanTypeInt64   = reflect.TypeOf(int64(0))
+	scanTypeFloat64 = reflect.TypeOf(float64(0))
+	scanTypeBool    = reflect.TypeOf(false)
+	scanTypeTime    = reflect.TypeOf(time.Time{})
+	scanTypeString  = reflect.TypeOf("")
+	scanTypeAny     = reflect.TypeOf((*interface{})(nil)).Elem()
+)
+
+type rows struct {
+	result *minds.QueryResult
+	pos    int
+}
+
+func newRows(result *minds.QueryResult) *rows {
+	return &rows{result: result}
+}
+
+func (r *rows) Columns() []string {
+	names := make([]string, len(r.result.Columns))
+	for i, column := range r.result.Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+func (r *rows) Close() error {
+	r.pos = len(r.result.Data)
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.result.Data) {
+		return io.EOF
+	}
+	row := r.result.Data[r.pos]
+	r.pos++
+	for i := range dest {
+		if i >= len(row) {
+			dest[i] = nil
+			continue
+		}
+		value, err := r.convert(i, row[i])
+		if err != nil {
+			return fmt.Errorf("mindsdb: column %s: %w", r.result.Columns[i].Name, err)
+		}
+		dest[i] = value
+	}
+	return nil
+}
+
+// ColumnTypeDatabaseTypeName returns the server's type name for a column.
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return strings.ToUpper(r.result.Columns[index].Type)
+}
+
+// ColumnTypeScanType returns the Go type values of a column are returned as.
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	switch typeClass(r.result.Columns[index].Type) {
+	case "int":
+		return scanTypeInt64
+	case "float":
+		return scanTypeFloat64
+	case "bool":
+		return scanTypeBool
+	case "time":
+		return scanTypeTime
+	case "string":
+		return scanTypeString
+	}
+	return scanTypeAny
+}
+
+// convert returns value as the Go type ColumnTypeScanType reports for the
+// column. Values of columns with unknown types are passed on as decoded.
+func (r *rows) convert(index int, value interface{}) (driver.Value, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch typeClass(r.result.Columns[index].Type) {
+	case "int":
+		return sqlvalue.ParseInt(value)
+	case "float":
+		return sqlvalue.ParseFloat(value)
+	case "bool":
+		return sqlvalue.ParseBool(value)
+	case "time":
+		return sqlvalue.ParseTime(value)
+	case "string":
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case json.Number:
+			return v.String(), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+		data, err := json.Marshal(value)
+		return string(data), err
+	}
+	switch value.(type) {
+	case bool, string, json.Number:
+		return sqlvalue.DriverValue(value), nil
+	}
+	// Nested objects and arrays are passed on as JSON.
+	return json.Marshal(value)
+}
+
+// typeClasses maps server type names to the Go types they scan into.
+var typeClasses = map[string]string{
+	"int": "int", "integer": "int", "tinyint": "int", "smallint": "int", "mediumint": "int", "bigint": "int",
+	"int8": "int", "int16": "int", "int32": "int", "int64": "int",
+	"uint8": "int", "uint16": "int", "uint32": "int", "uint64": "int",
+	"serial": "int", "bigserial": "int",
+	"float": "float", "float32": "float", "float64": "float", "double": "float",
+	"decimal": "float", "numeric": "float", "real": "float",
+	"bool": "bool", "boolean": "bool",
+	"date": "time", "datetime": "time", "timestamp": "time", "timestamptz": "time", "time": "time", "timetz": "time",
+	"char": "string", "varchar": "string", "nchar": "string", "nvarchar": "string", "character": "string",
+	"text": "string", "tinytext": "string", "mediumtext": "string", "longtext": "string",
+	"str": "string", "string": "string",
+}
+
+// typeClass groups server type names into the Go types they scan into. Only
+// the first word of the name counts, without size arguments, so that
+// "VARCHAR(255)" and "DOUBLE PRECISION" are recognised but "POINT" and
+// "INTERVAL" are not taken for integers.
+func typeClass(dbType string) string {
+	t := strings.ToLower(dbType)
+	if i := strings.IndexByte(t, '('); i >= 0 {
+		t = t[:i]
+	}
+	fields := strings.Fields(t)
+	if len(fields) == 0 {
+		return ""
+	}
+	return typeClasses[fields[0]]
+}
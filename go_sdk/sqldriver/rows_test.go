@@ -0,0 +1,57 @@
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"go_sdk/minds"
+)
+
+func TestRowsConvert(t *testing.T) {
+	tests := []struct {
+		dbType  string
+		value   interface{}
+		want    driver.Value
+		wantErr bool
+	}{
+		{"bigint", json.Number("42"), int64(42), false},
+		{"int", json.Number("4e1"), int64(40), false},
+		{"int", json.Number("1.5"), nil, true},
+		{"double", json.Number("3"), float64(3), false},
+		{"decimal(10,2)", "2.50", 2.5, false},
+		{"boolean", json.Number("1"), true, false},
+		{"timestamp", "2024-01-02T03:04:05Z", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), false},
+		{"date", "not a date", nil, true},
+		{"varchar(10)", json.Number("7"), "7", false},
+		{"text", map[string]interface{}{"a": true}, `{"a":true}`, false},
+		{"int", nil, nil, false},
+		{"", json.Number("7"), int64(7), false},
+		{"", json.Number("7.5"), 7.5, false},
+		{"json", []interface{}{json.Number("1")}, []byte(`[1]`), false},
+	}
+	for _, tt := range tests {
+		r := newRows(&minds.QueryResult{
+			Columns: []*minds.QueryColumn{{Name: "c", Type: tt.dbType}},
+			Data:    [][]interface{}{{tt.value}},
+		})
+		dest := make([]driver.Value, 1)
+		err := r.Next(dest)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s %#v: Next() error = %v, wantErr %v", tt.dbType, tt.value, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if !reflect.DeepEqual(dest[0], tt.want) {
+			t.Errorf("%s %#v: value = %#v, want %#v", tt.dbType, tt.value, dest[0], tt.want)
+		}
+		// Non-null values must have the type the column reports.
+		if scanType := r.ColumnTypeScanType(0); dest[0] != nil && scanType.Kind() != reflect.Interface && reflect.TypeOf(dest[0]) != scanType {
+			t.Errorf("%s %#v: value type %T, ColumnTypeScanType %s", tt.dbType, tt.value, dest[0], scanType)
+		}
+	}
+}